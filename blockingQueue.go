@@ -0,0 +1,202 @@
+package c3
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// blockingQueue is a queue guarded by a mutex.
+// Waiting goroutines wait on a channel that is closed
+// and replaced when the state they wait for may have changed.
+type blockingQueue struct {
+	mu       sync.Mutex
+	q        Queue
+	capacity int
+	closed   bool
+
+	notEmpty    chan struct{}
+	notFull     chan struct{}
+	takeWaiters int
+	putWaiters  int
+}
+
+func newBlockingQueue(capacity int) *blockingQueue {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &blockingQueue{
+		q:        NewQueue(),
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// signal wakes up the goroutines that wait on the channel, if there are any.
+func signal(c *chan struct{}, waiters int) {
+	if waiters > 0 {
+		close(*c)
+		*c = make(chan struct{})
+	}
+}
+
+func (b *blockingQueue) full() bool {
+	return b.capacity > 0 && b.q.Len() >= b.capacity
+}
+
+// enqueue adds the item, the lock must be held.
+func (b *blockingQueue) enqueue(item interface{}) {
+	b.q.Enqueue(item)
+	signal(&b.notEmpty, b.takeWaiters)
+}
+
+// dequeue removes the next item, the lock must be held.
+func (b *blockingQueue) dequeue() interface{} {
+	item, _ := b.q.Dequeue()
+	if !b.closed {
+		signal(&b.notFull, b.putWaiters)
+	}
+	return item
+}
+
+func (b *blockingQueue) Cap() int {
+	return b.capacity
+}
+
+func (b *blockingQueue) Enqueue(item interface{}) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || b.full() {
+		return false
+	}
+	b.enqueue(item)
+	return true
+}
+
+func (b *blockingQueue) Put(item interface{}) error {
+	return b.PutContext(context.Background(), item)
+}
+
+func (b *blockingQueue) TryPut(item interface{}, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return b.PutContext(ctx, item)
+}
+
+func (b *blockingQueue) PutContext(ctx context.Context, item interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		if b.closed {
+			return ErrClosed
+		}
+		if !b.full() {
+			b.enqueue(item)
+			return nil
+		}
+		if err := b.wait(ctx, b.notFull, &b.putWaiters); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *blockingQueue) Dequeue() (interface{}, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.q.Len() == 0 {
+		return defaultElementValue, false
+	}
+	return b.dequeue(), true
+}
+
+func (b *blockingQueue) Take() (interface{}, error) {
+	return b.TakeContext(context.Background())
+}
+
+func (b *blockingQueue) TryTake(timeout time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return b.TakeContext(ctx)
+}
+
+func (b *blockingQueue) TakeContext(ctx context.Context) (interface{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		if b.q.Len() > 0 {
+			return b.dequeue(), nil
+		}
+		if b.closed {
+			return defaultElementValue, ErrClosed
+		}
+		if err := b.wait(ctx, b.notEmpty, &b.takeWaiters); err != nil {
+			return defaultElementValue, err
+		}
+	}
+}
+
+// wait releases the lock until the channel is closed or the context is done,
+// the lock is held again when wait returns.
+func (b *blockingQueue) wait(ctx context.Context, c chan struct{}, waiters *int) error {
+	*waiters++
+	b.mu.Unlock()
+	var err error
+	select {
+	case <-c:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	b.mu.Lock()
+	*waiters--
+	return err
+}
+
+func (b *blockingQueue) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.notEmpty)
+	close(b.notFull)
+}
+
+func (b *blockingQueue) Peek() (interface{}, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Peek()
+}
+
+func (b *blockingQueue) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Len()
+}
+
+func (b *blockingQueue) Contains(item interface{}) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Contains(item)
+}
+
+func (b *blockingQueue) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.q.Clear()
+	if !b.closed {
+		signal(&b.notFull, b.putWaiters)
+	}
+}
+
+// Iterator returns an Iterator over a snapshot of the items in the queue.
+func (b *blockingQueue) Iterator() Iterator {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return snapshot(b.q)
+}
+
+func (b *blockingQueue) Consumer() Consumer {
+	return &blockingQueueConsumer{b, defaultElementValue}
+}
@@ -0,0 +1,30 @@
+package c3
+
+type blockingQueueConsumer struct {
+	b     *blockingQueue
+	value interface{}
+}
+
+// MoveNext waits for the next item,
+// returns false when the queue is closed and empty.
+func (c *blockingQueueConsumer) MoveNext() bool {
+	if c.b == nil {
+		return false
+	}
+	item, err := c.b.Take()
+	if err != nil {
+		c.Close()
+		return false
+	}
+	c.value = item
+	return true
+}
+
+func (c *blockingQueueConsumer) Value() interface{} {
+	return c.value
+}
+
+func (c *blockingQueueConsumer) Close() {
+	c.b = nil
+	c.value = defaultElementValue
+}
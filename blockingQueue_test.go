@@ -0,0 +1,104 @@
+package c3
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueueTakeWaitsForPut(t *testing.T) {
+	q := NewBlockingQueue(0)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Put(42)
+	}()
+	item, err := q.Take()
+	assert(t, nil, err, "err")
+	assert(t, 42, item, "item")
+}
+
+func TestBlockingQueueCapacity(t *testing.T) {
+	q := NewBlockingQueue(2)
+	assert(t, 2, q.Cap(), "q.Cap()")
+	assert(t, true, q.Enqueue(1), "Enqueue(1)")
+	assert(t, true, q.Enqueue(2), "Enqueue(2)")
+	assert(t, false, q.Enqueue(3), "Enqueue(3) on a full queue")
+
+	err := q.TryPut(3, 10*time.Millisecond)
+	assert(t, context.DeadlineExceeded, err, "TryPut on a full queue")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Dequeue()
+	}()
+	assert(t, nil, q.Put(3), "Put")
+	assertIndexOf(t, ToList(q), 3, 1)
+}
+
+func TestBlockingQueueTryTake(t *testing.T) {
+	q := NewBlockingQueue(0)
+	item, err := q.TryTake(10 * time.Millisecond)
+	assert(t, context.DeadlineExceeded, err, "err")
+	assert(t, nil, item, "item")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = q.TakeContext(ctx)
+	assert(t, context.Canceled, err, "err")
+
+	q.Put(1)
+	item, err = q.TakeContext(ctx)
+	assert(t, nil, err, "err with an item available")
+	assert(t, 1, item, "item")
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	q := NewBlockingQueue(1)
+	q.Put(1)
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- q.Put(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert(t, ErrClosed, <-errs, "Put on a closed queue")
+
+	item, err := q.Take()
+	assert(t, nil, err, "err")
+	assert(t, 1, item, "remaining item")
+
+	go func() {
+		_, err := q.Take()
+		errs <- err
+	}()
+	assert(t, ErrClosed, <-errs, "Take on a closed, empty queue")
+	assert(t, false, q.Enqueue(3), "Enqueue on a closed queue")
+}
+
+func TestBlockingQueueConsumer(t *testing.T) {
+	q := NewBlockingQueue(4)
+	var wg sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				q.Put(p*100 + n)
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		q.Close()
+	}()
+
+	seen := NewSet()
+	for c := q.Consumer(); c.MoveNext(); {
+		if !seen.Add(c.Value()) {
+			failf(t, "item %v consumed twice", c.Value())
+		}
+	}
+	assert(t, 400, seen.Len(), "seen.Len()")
+}
@@ -8,6 +8,12 @@
 //		- List: an indexable container.
 //		- Queue: a fifo container.
 //		- Stack: a lifo container.
+//		- Deque: a double-ended queue container.
+//		- PriorityQueue: a queue that dequeues the item with the highest priority first.
+//		- ConcurrentQueue, BlockingQueue: queues that can be shared between goroutines.
+//		- ConcurrentSet, ConcurrentMap: sharded containers that can be shared between goroutines.
+//		- Map: a key-value container.
+//		- SortedList, SortedSet, SortedMap: containers that keep their items sorted.
 //
 // It also provides a query api for those containers that looks like C#'s Linq
 //
@@ -19,8 +25,17 @@
 //
 package c3
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
 var defaultElementValue interface{}
 
+// ErrClosed is returned when adding items to, or waiting for items of a closed BlockingQueue.
+var ErrClosed = errors.New("c3: queue is closed")
+
 // Iterator provides a way to iterate over a container
 //
 // Usage:
@@ -43,12 +58,26 @@ type Iterable interface {
 	Iterator() Iterator
 }
 
-/*
+// Equatable is implemented by items that define their own equality.
+// Items that are equal must return the same Hashcode.
+// Equatable items are honored by the DefaultEquality.
 type Equatable interface {
+	// Returns true if the item equals the other item, false otherwise.
 	Equals(other interface{}) bool
+	// Returns the hashcode of the item.
 	Hashcode() int
 }
 
+// Equality is a strategy for comparing and hashing items.
+// Items that are equal must have the same hashcode.
+type Equality interface {
+	// Returns true if a equals b, false otherwise.
+	Equals(a, b interface{}) bool
+	// Returns the hashcode of the item.
+	Hashcode(item interface{}) int
+}
+
+/*
 type Comparable interface {
 	Compare(other interface{}) int
 }
@@ -141,6 +170,113 @@ type Set interface {
 	Intersection(other Set) Set
 }
 
+// A key-value container, that maps unique keys to values.
+// Iterating over a Map yields the KeyValue entries of the map.
+type Map interface {
+	Iterable
+	Clearer
+	// Returns the number of entries
+	Len() int
+	// Returns the value of the key and true,
+	// or nil and false if there is no such key
+	Get(key interface{}) (interface{}, bool)
+	// Put sets the value of the key,
+	// returns true if the key was added,
+	// false if the value of an existing key was replaced.
+	Put(key, value interface{}) bool
+	// Delete removes the key and its value from the map,
+	// returns true if the map was modified,
+	// false if it was not modified
+	Delete(key interface{}) bool
+	// Returns true if the key is in the map, false otherwise.
+	ContainsKey(key interface{}) bool
+	// Returns the keys of the map
+	Keys() Iterable
+	// Returns the values of the map
+	Values() Iterable
+	// Returns the KeyValue entries of the map
+	Entries() Iterable
+}
+
+// Grouping is a readonly list of items that share the same key.
+type Grouping interface {
+	ReadOnlyList
+	// Returns the key of the group
+	Key() interface{}
+}
+
+// Lookup is a readonly multi-valued index, that maps keys to one or more items.
+// Iterating over a Lookup yields a Grouping for every key.
+type Lookup interface {
+	Iterable
+	// Returns the number of keys
+	Len() int
+	// Returns the Grouping of the key and true,
+	// or nil and false if there is no such key
+	Get(key interface{}) (Grouping, bool)
+	// Returns the items of the key, or an empty Iterable if there is no such key
+	Items(key interface{}) Iterable
+	// Returns true if the key is in the lookup, false otherwise.
+	ContainsKey(key interface{}) bool
+	// Returns the keys of the lookup
+	Keys() Iterable
+}
+
+// Ordered provides range queries on a sorted container.
+// Items are equal if neither is less than the other.
+type Ordered interface {
+	// Returns the greatest item less than or equal to the item and true,
+	// or nil and false if there is no such item
+	Floor(item interface{}) (interface{}, bool)
+	// Returns the least item greater than or equal to the item and true,
+	// or nil and false if there is no such item
+	Ceiling(item interface{}) (interface{}, bool)
+	// Returns the items from the from item inclusive
+	// to the to item exclusive, in order.
+	Range(from, to interface{}) Iterable
+	// Returns the number of items less than the item,
+	// which is the index of the item if it is present.
+	Rank(item interface{}) int
+}
+
+// SortedList is a Bag that keeps its items sorted, and allows duplicate items.
+// Get returns the item with the given rank.
+type SortedList interface {
+	Bag
+	Indexable
+	Ordered
+}
+
+// SortedSet is a Set that keeps its items sorted.
+// Get returns the item with the given rank.
+type SortedSet interface {
+	Set
+	Indexable
+	Ordered
+}
+
+// SortedMap is a Map that keeps its entries sorted by key,
+// and iterates over its keys, values and entries in key order.
+// Keys are equal if neither is less than the other.
+type SortedMap interface {
+	Map
+	// Returns the entry with the given rank and true,
+	// or an empty KeyValue and false if the index is out of bounds
+	GetAt(index int) (KeyValue, bool)
+	// Returns the entry with the greatest key less than or equal to the key and true,
+	// or an empty KeyValue and false if there is no such entry
+	Floor(key interface{}) (KeyValue, bool)
+	// Returns the entry with the least key greater than or equal to the key and true,
+	// or an empty KeyValue and false if there is no such entry
+	Ceiling(key interface{}) (KeyValue, bool)
+	// Returns the entries with keys from the from key inclusive
+	// to the to key exclusive, in order.
+	Range(from, to interface{}) Iterable
+	// Returns the number of keys less than the key,
+	// which is the index of the key if it is present.
+	Rank(key interface{}) int
+}
+
 // Peeker provides a method to look at the next item without removing it from the container.
 type Peeker interface {
 	// Peek returns the next item without removing it from the container.
@@ -158,6 +294,13 @@ type Generator func() Generate
 // Returns the next item and true or nil and false if there are no more items.
 type Generate func() (interface{}, bool)
 
+// IteratorFunc is an Iterable that calls the function to create its iterators.
+// It adapts containers with a structurally compatible iterator, like heap.FibHeap,
+// so they can be queried:
+//
+//	NewQuery(IteratorFunc(func() Iterator { return h.Iterator() }))
+type IteratorFunc func() Iterator
+
 // Clearer provides a method to clear the container.
 type Clearer interface {
 	// Clear removes all items from the container.
@@ -169,6 +312,30 @@ type Consumer interface {
 	Iterator
 }
 
+// Closer is an optional Iterator extension for iterators that hold
+// on to resources, like a container or a goroutine.
+// Close releases those resources, after which MoveNext returns false.
+// An Iterator should be closed when it is abandoned before MoveNext returned false.
+type Closer interface {
+	Close()
+}
+
+// Errer is an optional Iterator extension for iterators that can stop
+// because of an error, like a cancelled context or a failing SelectE.
+// Err returns the error after MoveNext returned false, or nil if the
+// iterator reached the end of its items.
+// The iterators of the Q operators stop at the first error of their
+// source, and return that error from Err.
+type Errer interface {
+	Err() error
+}
+
+// KeyValue is a key and value pair.
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
 // Consumable is a container that provides a consuming iterator.
 type Consumable interface {
 	Consumer() Consumer
@@ -190,6 +357,65 @@ type Queue interface {
 	Dequeue() (interface{}, bool)
 }
 
+// A double-ended queue container, that allows adding and removing
+// items at both ends. The front of the deque has index 0.
+type Deque interface {
+	ReadOnlyList
+	Clearer
+	// Adds an item at the front of the deque,
+	// returns true if the deque was modified,
+	// false if it was not modified.
+	PushFront(item interface{}) bool
+	// Adds an item at the back of the deque,
+	// returns true if the deque was modified,
+	// false if it was not modified.
+	PushBack(item interface{}) bool
+	// Removes an item from the front of the deque,
+	// returns the item and true if the deque was modified,
+	// or nil and false if it was not modified.
+	PopFront() (interface{}, bool)
+	// Removes an item from the back of the deque,
+	// returns the item and true if the deque was modified,
+	// or nil and false if it was not modified.
+	PopBack() (interface{}, bool)
+	// Returns the item at the front of the deque and true,
+	// or nil and false if the deque is empty.
+	PeekFront() (interface{}, bool)
+	// Returns the item at the back of the deque and true,
+	// or nil and false if the deque is empty.
+	PeekBack() (interface{}, bool)
+	// Returns a Consumer that removes the items from the front of the deque.
+	FrontConsumer() Consumer
+	// Returns a Consumer that removes the items from the back of the deque.
+	BackConsumer() Consumer
+}
+
+// PriorityHandle identifies an item in a PriorityQueue.
+type PriorityHandle int
+
+// A priority queue container, that dequeues the item
+// with the highest priority, i.e. the least priority value, first.
+// Items with equal priorities are dequeued in the order in which they were added.
+// Iterating over a PriorityQueue yields its items in priority order,
+// without removing them from the queue.
+type PriorityQueue interface {
+	Queue
+	// Adds the item with the given priority to the queue,
+	// returns a handle to update the priority of the item.
+	EnqueueWithPriority(item, priority interface{}) PriorityHandle
+	// Returns the priority of the item of the handle and true,
+	// or nil and false if the item is not in the queue.
+	Priority(handle PriorityHandle) (interface{}, bool)
+	// Changes the priority of the item of the handle,
+	// returns true if the queue was modified,
+	// false if the item is not in the queue.
+	UpdatePriority(handle PriorityHandle, priority interface{}) bool
+	// Removes the item of the handle from the queue,
+	// returns true if the queue was modified,
+	// false if the item is not in the queue.
+	Remove(handle PriorityHandle) bool
+}
+
 // A simple stack container
 type Stack interface {
 	ReadOnlyBag
@@ -205,3 +431,100 @@ type Stack interface {
 	// nil and false if it was not modified.
 	Pop() (interface{}, bool)
 }
+
+// SyncList is a List that is safe for concurrent use by multiple goroutines.
+// Its Iterator iterates over a snapshot of the items,
+// so it does not panic when other goroutines modify the list.
+type SyncList interface {
+	List
+	// Adds the item if it is not in the list,
+	// returns true if the list was modified,
+	// false if it was not modified.
+	AddIfAbsent(item interface{}) bool
+	// Calls the action with the wrapped list while holding the write lock,
+	// to perform compound operations atomically.
+	// The action must not use the SyncList itself.
+	Do(action func(l List))
+}
+
+// SyncSet is a Set that is safe for concurrent use by multiple goroutines.
+// Its Iterator iterates over a snapshot of the items,
+// so it does not panic when other goroutines modify the set.
+// The set operations return regular, unsynchronized sets.
+type SyncSet interface {
+	Set
+	// Calls the action with the wrapped set while holding the write lock,
+	// to perform compound operations atomically.
+	// The action must not use the SyncSet itself.
+	Do(action func(s Set))
+}
+
+// SyncQueue is a Queue that is safe for concurrent use by multiple goroutines.
+// Its Iterator iterates over a snapshot of the items,
+// so it does not panic when other goroutines modify the queue.
+type SyncQueue interface {
+	Queue
+	// Removes the item at the head of the queue if the predicate holds for it,
+	// returns the item and true if the queue was modified,
+	// or nil and false if it was not modified.
+	DequeueIf(predicate Predicate) (interface{}, bool)
+	// Calls the action with the wrapped queue while holding the write lock,
+	// to perform compound operations atomically.
+	// The action must not use the SyncQueue itself.
+	Do(action func(q Queue))
+}
+
+// SyncStack is a Stack that is safe for concurrent use by multiple goroutines.
+// Its Iterator iterates over a snapshot of the items,
+// so it does not panic when other goroutines modify the stack.
+type SyncStack interface {
+	Stack
+	// Removes the item at the top of the stack if the predicate holds for it,
+	// returns the item and true if the stack was modified,
+	// or nil and false if it was not modified.
+	PopIf(predicate Predicate) (interface{}, bool)
+	// Calls the action with the wrapped stack while holding the write lock,
+	// to perform compound operations atomically.
+	// The action must not use the SyncStack itself.
+	Do(action func(s Stack))
+}
+
+// BlockingQueue is a Queue that is safe for concurrent use by multiple goroutines,
+// with methods that wait for an item or for free capacity.
+// Enqueue and Dequeue don't wait, they return false if the queue is full, closed or empty.
+// The Consumer of a BlockingQueue waits for items until the queue is closed and empty.
+type BlockingQueue interface {
+	Queue
+	// Returns the capacity of the queue, or 0 if the queue is unbounded.
+	Cap() int
+	// Appends an item at the tail of the queue, waits while the queue is full.
+	// Returns ErrClosed if the queue is closed.
+	Put(item interface{}) error
+	// Like Put, but returns the error of the context if it is done before the item is added.
+	PutContext(ctx context.Context, item interface{}) error
+	// Like Put, but returns context.DeadlineExceeded if the item
+	// cannot be added within the timeout.
+	TryPut(item interface{}, timeout time.Duration) error
+	// Removes an item from the head of the queue, waits while the queue is empty.
+	// Returns nil and ErrClosed if the queue is closed and empty.
+	Take() (interface{}, error)
+	// Like Take, but returns the error of the context if it is done before an item is available.
+	TakeContext(ctx context.Context) (interface{}, error)
+	// Like Take, but returns context.DeadlineExceeded if there
+	// is no item available within the timeout.
+	TryTake(timeout time.Duration) (interface{}, error)
+	// Closes the queue, which wakes up all waiting goroutines.
+	// Items can no longer be added to a closed queue,
+	// but the remaining items can still be taken.
+	Close()
+}
+
+// ConcurrentMap is a Map that is safe for concurrent use by multiple goroutines,
+// see NewConcurrentMap.
+type ConcurrentMap interface {
+	Map
+	// Sets the value of the key if the key is not in the map,
+	// returns the value and true if the key was added,
+	// or the existing value and false if the key was already in the map.
+	PutIfAbsent(key, value interface{}) (interface{}, bool)
+}
@@ -0,0 +1,35 @@
+package c3
+
+import "sync"
+
+// channelIterable is an Iterable over the items received from a channel.
+// The received items are kept, so the Iterable can be iterated more than once.
+type channelIterable struct {
+	mu     sync.Mutex
+	ch     <-chan interface{}
+	items  []interface{}
+	closed bool
+}
+
+// get returns the item at the index and true, receiving items from
+// the channel until the index is reached, or nil and false if
+// the channel is closed before that.
+func (c *channelIterable) get(index int) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.closed && index >= len(c.items) {
+		if item, ok := <-c.ch; ok {
+			c.items = append(c.items, item)
+		} else {
+			c.closed = true
+		}
+	}
+	if index < len(c.items) {
+		return c.items[index], true
+	}
+	return defaultElementValue, false
+}
+
+func (c *channelIterable) Iterator() Iterator {
+	return &channelIterator{c, -1, defaultElementValue}
+}
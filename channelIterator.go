@@ -0,0 +1,30 @@
+package c3
+
+type channelIterator struct {
+	c     *channelIterable
+	index int
+	value interface{}
+}
+
+func (i *channelIterator) MoveNext() bool {
+	if i.c == nil {
+		return false
+	}
+	value, ok := i.c.get(i.index + 1)
+	if !ok {
+		i.Close()
+		return false
+	}
+	i.index++
+	i.value = value
+	return true
+}
+
+func (i *channelIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *channelIterator) Close() {
+	i.c = nil
+	i.value = defaultElementValue
+}
@@ -0,0 +1,91 @@
+package c3
+
+import (
+	"context"
+	"testing"
+)
+
+func TestToChannel(t *testing.T) {
+	ch, cancel := ToChannel(Range(1, 5), 2)
+	defer cancel()
+	sum := 0
+	for item := range ch {
+		sum += item.(int)
+	}
+	assert(t, 15, sum, "sum")
+}
+
+func TestToChannelCancel(t *testing.T) {
+	r := &iteratorRecorder{source: ToList(Repeat(1000, 1))}
+	ch, cancel := NewQuery(r).ToChannel(0)
+	<-ch
+	<-ch
+	cancel()
+
+	assert(t, 1, len(r.iterators), "len(r.iterators)")
+	assertb(t, true, released(r.iterators[0]), "source iterator closed after cancel")
+	// the source can be modified after cancel returns, run with -race
+	r.source.(List).Add(2)
+
+	count := 0
+	for range ch {
+		count++
+	}
+	assertb(t, true, count <= 1, "received at most 1 item after cancel")
+	cancel()
+}
+
+func TestToChannelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count := 0
+	for range ToChannelContext(ctx, Range(1, 5), 0) {
+		count++
+	}
+	assert(t, 0, count, "count")
+}
+
+func TestFromChannelIteratesMoreThanOnce(t *testing.T) {
+	ch, cancel := ToChannel(Range(1, 4), 0)
+	defer cancel()
+	q := FromChannel(ch)
+
+	assert(t, 4, q.Count(), "first count")
+	assert(t, 4, q.Count(), "second count")
+	assert(t, 10, q.Where(func(item interface{}) bool { return item.(int) > 0 }).
+		Aggregate(0, func(item, sum interface{}) interface{} { return sum.(int) + item.(int) }),
+		"sum")
+}
+
+func TestPumpToBag(t *testing.T) {
+	ch, cancel := ToChannel(Range(1, 5), 0)
+	defer cancel()
+	s := NewSet()
+	s.Add(3)
+	assert(t, 4, PumpToBag(ch, s), "items added")
+	assert(t, 5, s.Len(), "s.Len()")
+}
+
+func TestPumpToQueue(t *testing.T) {
+	ch, cancel := ToChannel(Range(1, 5), 0)
+	defer cancel()
+	q := NewQueue()
+	assert(t, 5, PumpToQueue(ch, q), "items enqueued")
+	assertIndexOf(t, ToList(q), 1, 0)
+	assertIndexOf(t, ToList(q), 5, 4)
+}
+
+func TestPumpToBlockingQueue(t *testing.T) {
+	ch, cancel := ToChannel(Range(1, 100), 0)
+	defer cancel()
+	q := NewBlockingQueue(2)
+	go func() {
+		defer q.Close()
+		PumpToQueue(ch, q)
+	}()
+	count := 0
+	for c := q.Consumer(); c.MoveNext(); {
+		count++
+	}
+	assert(t, 100, count, "count")
+}
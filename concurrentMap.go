@@ -0,0 +1,130 @@
+package c3
+
+import "sync"
+
+// concurrentMap is a Map that is sharded by the hashcode of the keys over
+// independently locked maps, so goroutines that use different shards don't contend.
+type concurrentMap struct {
+	shards [shardCount]concurrentMapShard
+}
+
+type concurrentMapShard struct {
+	mu    sync.RWMutex
+	items map[interface{}]interface{}
+}
+
+func newConcurrentMap() *concurrentMap {
+	m := &concurrentMap{}
+	for n := range m.shards {
+		m.shards[n].items = make(map[interface{}]interface{})
+	}
+	return m
+}
+
+func (m *concurrentMap) shard(key interface{}) *concurrentMapShard {
+	return &m.shards[shardOf(DefaultEquality.Hashcode(key))]
+}
+
+func (m *concurrentMap) Get(key interface{}) (interface{}, bool) {
+	shard := m.shard(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	value, ok := shard.items[key]
+	return value, ok
+}
+
+func (m *concurrentMap) Put(key, value interface{}) bool {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	_, replaced := shard.items[key]
+	shard.items[key] = value
+	return !replaced
+}
+
+func (m *concurrentMap) PutIfAbsent(key, value interface{}) (interface{}, bool) {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if existing, ok := shard.items[key]; ok {
+		return existing, false
+	}
+	shard.items[key] = value
+	return value, true
+}
+
+func (m *concurrentMap) Delete(key interface{}) bool {
+	shard := m.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if _, ok := shard.items[key]; ok {
+		delete(shard.items, key)
+		return true
+	}
+	return false
+}
+
+func (m *concurrentMap) ContainsKey(key interface{}) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Len returns the sum of the entry counts of the shards,
+// which is only exact when there are no concurrent modifications.
+func (m *concurrentMap) Len() int {
+	length := 0
+	for n := range m.shards {
+		shard := &m.shards[n]
+		shard.mu.RLock()
+		length += len(shard.items)
+		shard.mu.RUnlock()
+	}
+	return length
+}
+
+// Clear clears the shards one after the other.
+func (m *concurrentMap) Clear() {
+	for n := range m.shards {
+		shard := &m.shards[n]
+		shard.mu.Lock()
+		shard.items = make(map[interface{}]interface{})
+		shard.mu.Unlock()
+	}
+}
+
+func (m *concurrentMap) Iterator() Iterator {
+	return m.Entries().Iterator()
+}
+
+func (m *concurrentMap) Keys() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return key
+	})
+}
+
+func (m *concurrentMap) Values() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return value
+	})
+}
+
+func (m *concurrentMap) Entries() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return KeyValue{key, value}
+	})
+}
+
+// iterable creates a weakly consistent Iterable that selects
+// an item for every entry in the map, see shardedIterable.
+func (m *concurrentMap) iterable(selector func(key, value interface{}) interface{}) Iterable {
+	return shardedIterable(func(n int) []interface{} {
+		shard := &m.shards[n]
+		shard.mu.RLock()
+		defer shard.mu.RUnlock()
+		items := make([]interface{}, 0, len(shard.items))
+		for key, value := range shard.items {
+			items = append(items, selector(key, value))
+		}
+		return items
+	})
+}
@@ -0,0 +1,119 @@
+package c3
+
+import "sync/atomic"
+
+// concurrentQueue is a lock-free multi-producer, multi-consumer queue,
+// based on the algorithm by Michael and Scott:
+// "Simple, Fast, and Practical Non-Blocking and Blocking Concurrent Queue Algorithms".
+//
+// head always points to a sentinel node, the first item is in head.next.
+// A dequeued node becomes the new sentinel, so the last dequeued item
+// is kept alive until the next Dequeue.
+type concurrentQueue struct {
+	head   atomic.Pointer[concurrentEntry]
+	tail   atomic.Pointer[concurrentEntry]
+	length atomic.Int64
+}
+
+type concurrentEntry struct {
+	item interface{}
+	next atomic.Pointer[concurrentEntry]
+}
+
+func newConcurrentQueue() *concurrentQueue {
+	q := &concurrentQueue{}
+	sentinel := &concurrentEntry{item: defaultElementValue}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+func (q *concurrentQueue) Enqueue(item interface{}) bool {
+	e := &concurrentEntry{item: item}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail is lagging behind, help the other producer
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, e) {
+			q.tail.CompareAndSwap(tail, e)
+			q.length.Add(1)
+			return true
+		}
+	}
+}
+
+func (q *concurrentQueue) Dequeue() (interface{}, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			return defaultElementValue, false
+		}
+		if head == tail {
+			// tail is lagging behind, help the producer
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if q.head.CompareAndSwap(head, next) {
+			q.length.Add(-1)
+			return next.item, true
+		}
+	}
+}
+
+func (q *concurrentQueue) Peek() (interface{}, bool) {
+	if next := q.head.Load().next.Load(); next != nil {
+		return next.item, true
+	}
+	return defaultElementValue, false
+}
+
+// Len returns the item count. The count is only exact
+// when there are no concurrent Enqueue or Dequeue calls.
+func (q *concurrentQueue) Len() int {
+	if length := q.length.Load(); length > 0 {
+		return int(length)
+	}
+	return 0
+}
+
+func (q *concurrentQueue) Contains(item interface{}) bool {
+	for e := q.head.Load().next.Load(); e != nil; e = e.next.Load() {
+		if e.item == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear dequeues all items that are in the queue when Clear is called,
+// items that are enqueued concurrently may or may not be removed.
+func (q *concurrentQueue) Clear() {
+	for n := q.Len(); n > 0; n-- {
+		if _, ok := q.Dequeue(); !ok {
+			return
+		}
+	}
+}
+
+// Iterator returns a weakly consistent Iterator, that never panics,
+// and that may or may not see items that are enqueued or dequeued
+// after it was created.
+func (q *concurrentQueue) Iterator() Iterator {
+	return &concurrentQueueIterator{next: q.head.Load().next.Load()}
+}
+
+func (q *concurrentQueue) Consumer() Consumer {
+	return &dequeConsumer{q.Dequeue, defaultElementValue}
+}
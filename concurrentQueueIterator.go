@@ -0,0 +1,25 @@
+package c3
+
+type concurrentQueueIterator struct {
+	next  *concurrentEntry
+	value interface{}
+}
+
+func (i *concurrentQueueIterator) MoveNext() bool {
+	if i.next == nil {
+		i.value = defaultElementValue
+		return false
+	}
+	i.value = i.next.item
+	i.next = i.next.next.Load()
+	return true
+}
+
+func (i *concurrentQueueIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *concurrentQueueIterator) Close() {
+	i.next = nil
+	i.value = defaultElementValue
+}
@@ -0,0 +1,95 @@
+package c3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentQueueFifo(t *testing.T) {
+	q := NewConcurrentQueue()
+	_, ok := q.Dequeue()
+	assert(t, false, ok, "ok on empty queue")
+	_, ok = q.Peek()
+	assert(t, false, ok, "peek ok on empty queue")
+
+	for n := 0; n < 5; n++ {
+		q.Enqueue(n)
+	}
+	assert(t, 5, q.Len(), "q.Len()")
+	assert(t, true, q.Contains(3), "q.Contains(3)")
+	assert(t, false, q.Contains(5), "q.Contains(5)")
+
+	item, ok := q.Peek()
+	assert(t, true, ok, "peek ok")
+	assert(t, 0, item, "peek item")
+
+	for n := 0; n < 5; n++ {
+		item, ok := q.Dequeue()
+		assert(t, true, ok, "ok")
+		assert(t, n, item, "item")
+	}
+	assert(t, 0, q.Len(), "q.Len()")
+}
+
+func TestConcurrentQueueIterateWhileModifying(t *testing.T) {
+	q := NewConcurrentQueue()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	count := 0
+	for i := q.Iterator(); i.MoveNext(); {
+		q.Dequeue()
+		count++
+	}
+	assert(t, 2, count, "count")
+
+	q.Enqueue(3)
+	q.Clear()
+	assert(t, 0, q.Len(), "q.Len() after Clear")
+}
+
+func TestConcurrentQueueProducersAndConsumers(t *testing.T) {
+	const producers, items = 4, 1000
+	q := NewConcurrentQueue()
+
+	var producing sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			for n := 0; n < items; n++ {
+				q.Enqueue(p*items + n)
+			}
+		}(p)
+	}
+
+	done := make(chan bool)
+	go func() {
+		producing.Wait()
+		close(done)
+	}()
+
+	seen := SynchronizedSet(NewSet())
+	var consuming sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				for c := q.Consumer(); c.MoveNext(); {
+					if !seen.Add(c.Value()) {
+						failf(t, "item %v consumed twice", c.Value())
+					}
+				}
+				select {
+				case <-done:
+					if q.Len() == 0 {
+						return
+					}
+				default:
+				}
+			}
+		}()
+	}
+	consuming.Wait()
+	assert(t, producers*items, seen.Len(), "seen.Len()")
+}
@@ -0,0 +1,140 @@
+package c3
+
+import "sync"
+
+// concurrentSet is a Set that is sharded by hashcode over independently
+// locked sets, so goroutines that use different shards don't contend.
+// The shards are regular sets if eq is nil, or hash sets that use eq.
+type concurrentSet struct {
+	eq     Equality
+	hash   Equality
+	shards [shardCount]concurrentSetShard
+}
+
+type concurrentSetShard struct {
+	mu sync.RWMutex
+	s  Set
+}
+
+func newConcurrentSet(eq Equality) *concurrentSet {
+	s := &concurrentSet{eq: eq, hash: eq}
+	if eq == nil {
+		s.hash = DefaultEquality
+	}
+	for n := range s.shards {
+		s.shards[n].s = s.newSet()
+	}
+	return s
+}
+
+func (s *concurrentSet) shard(item interface{}) *concurrentSetShard {
+	return &s.shards[shardOf(s.hash.Hashcode(item))]
+}
+
+// newSet creates a regular set with the same equality.
+func (s *concurrentSet) newSet() Set {
+	if s.eq == nil {
+		return NewSet()
+	}
+	return NewSetWith(s.eq)
+}
+
+func (s *concurrentSet) Add(item interface{}) bool {
+	shard := s.shard(item)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return shard.s.Add(item)
+}
+
+func (s *concurrentSet) Delete(item interface{}) bool {
+	shard := s.shard(item)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	return shard.s.Delete(item)
+}
+
+func (s *concurrentSet) Contains(item interface{}) bool {
+	shard := s.shard(item)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return shard.s.Contains(item)
+}
+
+// Len returns the sum of the item counts of the shards,
+// which is only exact when there are no concurrent modifications.
+func (s *concurrentSet) Len() int {
+	length := 0
+	for n := range s.shards {
+		shard := &s.shards[n]
+		shard.mu.RLock()
+		length += shard.s.Len()
+		shard.mu.RUnlock()
+	}
+	return length
+}
+
+// Clear clears the shards one after the other.
+func (s *concurrentSet) Clear() {
+	for n := range s.shards {
+		shard := &s.shards[n]
+		shard.mu.Lock()
+		shard.s.Clear()
+		shard.mu.Unlock()
+	}
+}
+
+// Iterator returns a weakly consistent Iterator, see shardedIterable.
+func (s *concurrentSet) Iterator() Iterator {
+	return shardedIterable(func(n int) []interface{} {
+		shard := &s.shards[n]
+		shard.mu.RLock()
+		defer shard.mu.RUnlock()
+		return ToSlice(shard.s)
+	}).Iterator()
+}
+
+func (s *concurrentSet) Intersection(other Set) Set {
+	result := s.newSet()
+	for i := s.Iterator(); i.MoveNext(); {
+		if other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *concurrentSet) Difference(other Set) Set {
+	result := s.newSet()
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *concurrentSet) Union(other Set) Set {
+	result := s.newSet()
+	for i := s.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	return result
+}
+
+func (s *concurrentSet) SymmetricDifference(other Set) Set {
+	result := s.newSet()
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		if !s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
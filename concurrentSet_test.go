@@ -0,0 +1,125 @@
+package c3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSetAddDelete(t *testing.T) {
+	s := NewConcurrentSet()
+	assert(t, true, s.Add(1), "s.Add(1)")
+	assert(t, false, s.Add(1), "s.Add(1) again")
+	assert(t, true, s.Add("one"), "s.Add(\"one\")")
+	assert(t, 2, s.Len(), "s.Len()")
+	assert(t, true, s.Contains("one"), "s.Contains(\"one\")")
+	assert(t, true, s.Delete(1), "s.Delete(1)")
+	assert(t, false, s.Delete(1), "s.Delete(1) again")
+	assert(t, false, s.Contains(1), "s.Contains(1)")
+	s.Clear()
+	assert(t, 0, s.Len(), "s.Len() after Clear")
+}
+
+func TestConcurrentSetWithEquality(t *testing.T) {
+	s := NewConcurrentSetWith(IgnoreCase)
+	s.Add("Hello")
+	assert(t, false, s.Add("HELLO"), "s.Add(\"HELLO\")")
+	assert(t, true, s.Contains("hello"), "s.Contains(\"hello\")")
+}
+
+func TestConcurrentSetConcurrentAdd(t *testing.T) {
+	s := NewConcurrentSet()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 1000; n++ {
+				s.Add(n)
+				s.Contains(n)
+			}
+		}()
+	}
+	wg.Wait()
+	assert(t, 1000, s.Len(), "s.Len()")
+	assert(t, 1000, NewQuery(s).Count(), "iterated count")
+}
+
+func TestConcurrentSetIterateWhileModifying(t *testing.T) {
+	s := NewConcurrentSet()
+	for n := 0; n < 100; n++ {
+		s.Add(n)
+	}
+	for i := s.Iterator(); i.MoveNext(); {
+		s.Delete(i.Value())
+		s.Add(-1 - i.Value().(int))
+	}
+	assert(t, true, s.Len() >= 100, "s.Len() >= 100")
+}
+
+func TestConcurrentSetOperations(t *testing.T) {
+	a := NewConcurrentSet()
+	b := NewConcurrentSet()
+	for n := 1; n <= 3; n++ {
+		a.Add(n)
+		b.Add(n + 1)
+	}
+
+	union := a.Union(b)
+	assert(t, 4, union.Len(), "Union")
+	assert(t, 2, a.Intersection(b).Len(), "Intersection")
+	assert(t, true, a.Difference(b).Contains(1), "Difference")
+	assert(t, 2, a.SymmetricDifference(b).Len(), "SymmetricDifference")
+	if _, ok := union.(*set); !ok {
+		fail(t, "expected a regular set")
+	}
+}
+
+func TestConcurrentMap(t *testing.T) {
+	m := NewConcurrentMap()
+	assert(t, true, m.Put("a", 1), "m.Put(\"a\", 1)")
+	assert(t, false, m.Put("a", 2), "m.Put(\"a\", 2)")
+	value, ok := m.Get("a")
+	assert(t, true, ok, "ok")
+	assert(t, 2, value, "value")
+
+	value, added := m.PutIfAbsent("a", 3)
+	assert(t, false, added, "added")
+	assert(t, 2, value, "existing value")
+	value, added = m.PutIfAbsent("b", 3)
+	assert(t, true, added, "added")
+	assert(t, 3, value, "value")
+
+	assert(t, 2, m.Len(), "m.Len()")
+	assert(t, 2, NewQuery(m.Keys()).Count(), "keys")
+	assert(t, 5, NewQuery(m.Values()).Aggregate(0, func(item, sum interface{}) interface{} {
+		return sum.(int) + item.(int)
+	}), "sum of values")
+	assertContains(t, ToList(m.Entries()), KeyValue{"b", 3}, true)
+
+	assert(t, true, m.Delete("a"), "m.Delete(\"a\")")
+	assert(t, false, m.ContainsKey("a"), "m.ContainsKey(\"a\")")
+	m.Clear()
+	assert(t, 0, m.Len(), "m.Len() after Clear")
+}
+
+func TestConcurrentMapPutIfAbsent(t *testing.T) {
+	m := NewConcurrentMap()
+	winners := NewConcurrentSet()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if _, added := m.PutIfAbsent(n, w); added {
+					winners.Add(n)
+				}
+				for i := m.Iterator(); i.MoveNext(); {
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert(t, 100, m.Len(), "m.Len()")
+	assert(t, 100, winners.Len(), "winners.Len()")
+}
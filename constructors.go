@@ -15,11 +15,119 @@ func NewQueue() Queue {
 	return &queue{nil, nil, nil, 0, 0, 0}
 }
 
+// NewConcurrentQueue creates a new, empty Queue that is safe for concurrent use
+// by multiple producers and consumers without locking.
+// Its Iterator is weakly consistent and does not panic on concurrent modification.
+func NewConcurrentQueue() Queue {
+	return newConcurrentQueue()
+}
+
+// NewBlockingQueue creates a new, empty BlockingQueue that holds at most
+// capacity items, or an unbounded BlockingQueue if capacity is 0 or less.
+func NewBlockingQueue(capacity int) BlockingQueue {
+	return newBlockingQueue(capacity)
+}
+
 // NewSet creates a new, empty Set.
 func NewSet() Set {
 	return &set{0, make(map[interface{}]bool)}
 }
 
+// NewSetWith creates a new, empty Set that uses the Equality
+// to compare items. If eq is nil the DefaultEquality is used.
+func NewSetWith(eq Equality) Set {
+	if eq == nil {
+		eq = DefaultEquality
+	}
+	return &hashSet{0, 0, eq, make(map[int][]interface{})}
+}
+
+// NewListWith creates a new, empty List that uses the Equality
+// to compare items. If eq is nil the DefaultEquality is used.
+func NewListWith(eq Equality) List {
+	l := newList()
+	if eq == nil {
+		eq = DefaultEquality
+	}
+	l.eq = eq
+	return l
+}
+
+// NewMap creates a new, empty Map.
+func NewMap() Map {
+	return &hashMap{0, make(map[interface{}]interface{})}
+}
+
+// NewConcurrentSet creates a new, empty Set that is safe for concurrent use
+// by multiple goroutines. The items are sharded by hashcode over independently
+// locked segments. Len is the sum of the lengths of the segments, and the Iterator
+// is weakly consistent: it never panics, and it may or may not see concurrent
+// modifications. The set operations return regular sets.
+func NewConcurrentSet() Set {
+	return newConcurrentSet(nil)
+}
+
+// NewConcurrentSetWith creates a new, empty concurrent Set that uses the Equality
+// to compare items, see NewConcurrentSet. If eq is nil the DefaultEquality is used.
+func NewConcurrentSetWith(eq Equality) Set {
+	if eq == nil {
+		eq = DefaultEquality
+	}
+	return newConcurrentSet(eq)
+}
+
+// NewConcurrentMap creates a new, empty Map that is safe for concurrent use
+// by multiple goroutines. The entries are sharded by the hashcode of the keys
+// over independently locked segments.
+// Len is the sum of the lengths of the segments, and the iterables are weakly consistent:
+// they never panic, and they may or may not see concurrent modifications.
+func NewConcurrentMap() ConcurrentMap {
+	return newConcurrentMap()
+}
+
+// NewDeque creates a new, empty Deque.
+func NewDeque() Deque {
+	return newDeque()
+}
+
+// NewSortedList creates a new, empty SortedList that uses the Lesser to sort its items.
+func NewSortedList(less Lesser) SortedList {
+	return &sortedList{sortedItems{newTree(less)}}
+}
+
+// NewSortedSet creates a new, empty SortedSet that uses the Lesser to sort its items.
+func NewSortedSet(less Lesser) SortedSet {
+	return &sortedSet{sortedItems{newTree(less)}}
+}
+
+// NewSortedMap creates a new, empty SortedMap that uses the Lesser to sort its keys.
+func NewSortedMap(less Lesser) SortedMap {
+	return &sortedMap{newTree(less)}
+}
+
+// NewPriorityQueue creates a new, empty PriorityQueue that uses the Lesser
+// to compare priorities. Enqueue uses the item as its own priority.
+func NewPriorityQueue(less Lesser) PriorityQueue {
+	if less == nil {
+		panic("Lesser parameter invalid")
+	}
+	return newPriorityQueue(less)
+}
+
+// NewNumericPriorityQueue creates a new, empty PriorityQueue with numeric priorities.
+// The priorities must be ints or floats, and Enqueue uses the priority function to
+// compute the priority of an item.
+func NewNumericPriorityQueue(priority func(item interface{}) float64) PriorityQueue {
+	if priority == nil {
+		panic("priority parameter invalid")
+	}
+	q := newPriorityQueue(func(a, b interface{}) bool {
+		return toFloat(a) < toFloat(b)
+	})
+	q.priority = priority
+	return q
+}
+
 // NewStack creates a new, empty Stack.
 func NewStack() Stack {
 	return &stack{newList()}
@@ -75,12 +183,21 @@ func QueueOf(items ...interface{}) Queue {
 	return q
 }
 
+// DequeOf creates a Deque with the given items, the first item at the front.
+func DequeOf(items ...interface{}) Deque {
+	d := newDeque()
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return d
+}
+
 // ListOf creates a List with the given items.
 func ListOf(items ...interface{}) List {
 	l := len(items)
 	x := make([]interface{}, l)
 	copy(x, items)
-	return &list{0, x}
+	return &list{0, x, nil}
 }
 
 // MakeIterable converts the Generator function into an Iterable.
@@ -134,6 +251,10 @@ func BagOf(items ...interface{}) Bag {
 	return ListOf(items...)
 }
 
+func newDeque() *deque {
+	return &deque{0, make([]interface{}, 4), 0, 0}
+}
+
 func newList() *list {
-	return &list{0, make([]interface{}, 0, 4)}
+	return &list{0, make([]interface{}, 0, 4), nil}
 }
@@ -1,6 +1,10 @@
 package c3
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+)
 
 func TestConsumerOfChannel(t *testing.T) {
 	ch := make(chan interface{}, 10)
@@ -12,3 +16,26 @@ func TestConsumerOfChannel(t *testing.T) {
 	}
 	assert(t, 10, count, "iterations")
 }
+
+func TestConsumerContextCancel(t *testing.T) {
+	ch := make(chan interface{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	go func() { ch <- 1 }()
+
+	c := WrapConsumerContext(ctx, ch)
+	assertb(t, true, c.MoveNext(), "c.MoveNext()")
+	assert(t, 1, c.Value(), "c.Value()")
+	// nothing is sent anymore, so this blocks until the timeout
+	assertb(t, false, c.MoveNext(), "c.MoveNext()")
+	assert(t, nil, c.Value(), "c.Value()")
+	assert(t, context.DeadlineExceeded, Err(c), "Err(c)")
+}
+
+func TestConsumerContextClosedChannel(t *testing.T) {
+	ch := make(chan interface{})
+	close(ch)
+	c := WrapConsumerContext(context.Background(), ch)
+	assertb(t, false, c.MoveNext(), "c.MoveNext()")
+	assert(t, nil, Err(c), "Err(c)")
+}
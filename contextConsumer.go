@@ -0,0 +1,41 @@
+package c3
+
+import "context"
+
+type contextConsumer struct {
+	ctx      context.Context
+	c        <-chan interface{}
+	hasvalue bool
+	value    interface{}
+	err      error
+}
+
+func (i *contextConsumer) MoveNext() bool {
+	i.hasvalue = false
+	i.value = defaultElementValue
+	if i.err == nil {
+		i.err = i.ctx.Err()
+	}
+	if i.err != nil {
+		return false
+	}
+	select {
+	case value, ok := <-i.c:
+		i.value = value
+		i.hasvalue = ok
+	case <-i.ctx.Done():
+		i.err = i.ctx.Err()
+	}
+	return i.hasvalue
+}
+
+func (i *contextConsumer) Value() interface{} {
+	if i.hasvalue {
+		return i.value
+	}
+	return defaultElementValue
+}
+
+func (i *contextConsumer) Err() error {
+	return i.err
+}
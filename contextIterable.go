@@ -0,0 +1,12 @@
+package c3
+
+import "context"
+
+type contextIterable struct {
+	items Iterable
+	ctx   context.Context
+}
+
+func (i *contextIterable) Iterator() Iterator {
+	return &contextIterator{items: i.items.Iterator(), ctx: i.ctx}
+}
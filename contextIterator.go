@@ -0,0 +1,39 @@
+package c3
+
+import "context"
+
+type contextIterator struct {
+	items Iterator
+	ctx   context.Context
+	err   error
+}
+
+func (i *contextIterator) MoveNext() bool {
+	if i.err != nil {
+		return false
+	}
+	if err := i.ctx.Err(); err != nil {
+		i.err = err
+		Close(i.items)
+		return false
+	}
+	return i.items.MoveNext()
+}
+
+func (i *contextIterator) Value() interface{} {
+	if i.err != nil {
+		return defaultElementValue
+	}
+	return i.items.Value()
+}
+
+func (i *contextIterator) Close() {
+	Close(i.items)
+}
+
+func (i *contextIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return Err(i.items)
+}
@@ -0,0 +1,40 @@
+package c3
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQueryWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	i := NewQuery(Range(0, 99)).
+		WithContext(ctx).
+		Select(func(v interface{}) interface{} { return v.(int) * 2 }).
+		Where(func(v interface{}) bool { return true }).
+		Concat(Range(0, 9)).
+		Iterator()
+	for i.MoveNext() {
+		if count++; count == 10 {
+			cancel()
+		}
+	}
+	assert(t, 10, count, "count")
+	assert(t, context.Canceled, Err(i), "Err(i)")
+}
+
+func TestQueryWithContextCompletes(t *testing.T) {
+	i := NewQuery(Range(0, 9)).WithContext(context.Background()).Iterator()
+	count := 0
+	for i.MoveNext() {
+		count++
+	}
+	assert(t, 10, count, "count")
+	assert(t, nil, Err(i), "Err(i)")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert(t, 0, NewQuery(Range(0, 9)).WithContext(ctx).Count(), "Count()")
+}
@@ -0,0 +1,154 @@
+package c3
+
+// deque is a ring buffer, the items are stored
+// in items[head:head+length] modulo len(items).
+type deque struct {
+	version int
+	items   []interface{}
+	head    int
+	length  int
+}
+
+// index converts an index in the deque into an index in the ring buffer
+func (d *deque) index(index int) int {
+	return (d.head + index) % len(d.items)
+}
+
+// grow doubles the capacity of the ring buffer if it is full
+func (d *deque) grow() {
+	if d.length < len(d.items) {
+		return
+	}
+	items := make([]interface{}, len(d.items)*2)
+	n := copy(items, d.items[d.head:])
+	copy(items[n:], d.items[:d.head])
+	d.items = items
+	d.head = 0
+}
+
+func (d *deque) Len() int {
+	return d.length
+}
+
+func (d *deque) Clear() {
+	if d.length == 0 {
+		return
+	}
+	if len(d.items) <= 1024 {
+		for i := range d.items {
+			d.items[i] = defaultElementValue
+		}
+	} else {
+		d.items = make([]interface{}, 4)
+	}
+	d.head = 0
+	d.length = 0
+	d.version++
+}
+
+func (d *deque) PushFront(item interface{}) bool {
+	d.grow()
+	d.head = (d.head + len(d.items) - 1) % len(d.items)
+	d.items[d.head] = item
+	d.length++
+	d.version++
+	return true
+}
+
+func (d *deque) PushBack(item interface{}) bool {
+	d.grow()
+	d.items[d.index(d.length)] = item
+	d.length++
+	d.version++
+	return true
+}
+
+func (d *deque) PopFront() (interface{}, bool) {
+	if d.length == 0 {
+		return defaultElementValue, false
+	}
+	item := d.items[d.head]
+	d.items[d.head] = defaultElementValue
+	d.head = d.index(1)
+	d.length--
+	d.version++
+	return item, true
+}
+
+func (d *deque) PopBack() (interface{}, bool) {
+	if d.length == 0 {
+		return defaultElementValue, false
+	}
+	index := d.index(d.length - 1)
+	item := d.items[index]
+	d.items[index] = defaultElementValue
+	d.length--
+	d.version++
+	return item, true
+}
+
+func (d *deque) PeekFront() (interface{}, bool) {
+	return d.First()
+}
+
+func (d *deque) PeekBack() (interface{}, bool) {
+	return d.Last()
+}
+
+func (d *deque) First() (interface{}, bool) {
+	return d.Get(0)
+}
+
+func (d *deque) Last() (interface{}, bool) {
+	return d.Get(d.length - 1)
+}
+
+func (d *deque) Get(index int) (interface{}, bool) {
+	if 0 > index || index >= d.length {
+		return defaultElementValue, false
+	}
+	return d.items[d.index(index)], true
+}
+
+func (d *deque) Contains(item interface{}) bool {
+	_, ok := d.IndexOf(item)
+	return ok
+}
+
+func (d *deque) IndexOf(item interface{}) (int, bool) {
+	return d.NextIndexOf(-1, item)
+}
+
+func (d *deque) NextIndexOf(offset int, item interface{}) (int, bool) {
+	for index := max(-1, offset) + 1; 0 <= index && index < d.length; index++ {
+		if d.items[d.index(index)] == item {
+			return index, true
+		}
+	}
+	return -1, false
+}
+
+func (d *deque) LastIndexOf(item interface{}) (int, bool) {
+	return d.PrevIndexOf(d.length, item)
+}
+
+func (d *deque) PrevIndexOf(offset int, item interface{}) (int, bool) {
+	for index := min(offset, d.length) - 1; 0 <= index && index < d.length; index-- {
+		if d.items[d.index(index)] == item {
+			return index, true
+		}
+	}
+	return -1, false
+}
+
+func (d *deque) Iterator() Iterator {
+	return &dequeIterator{d, d.version, -1, defaultElementValue}
+}
+
+func (d *deque) FrontConsumer() Consumer {
+	return &dequeConsumer{d.PopFront, defaultElementValue}
+}
+
+func (d *deque) BackConsumer() Consumer {
+	return &dequeConsumer{d.PopBack, defaultElementValue}
+}
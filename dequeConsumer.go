@@ -0,0 +1,16 @@
+package c3
+
+type dequeConsumer struct {
+	pop   Consume
+	value interface{}
+}
+
+func (c *dequeConsumer) MoveNext() bool {
+	value, ok := c.pop()
+	c.value = value
+	return ok
+}
+
+func (c *dequeConsumer) Value() interface{} {
+	return c.value
+}
@@ -0,0 +1,37 @@
+package c3
+
+type dequeIterator struct {
+	d       *deque
+	version int
+	index   int
+	value   interface{}
+}
+
+func (i *dequeIterator) MoveNext() bool {
+	if i.d == nil {
+		return false
+	}
+
+	if i.version != i.d.version {
+		i.value = defaultElementValue
+		panic("Concurrent modification detected")
+	}
+
+	if i.index < i.d.length-1 {
+		i.index++
+		i.value = i.d.items[i.d.index(i.index)]
+		return true
+	}
+
+	i.value = defaultElementValue
+	return false
+}
+
+func (i *dequeIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *dequeIterator) Close() {
+	i.d = nil
+	i.value = defaultElementValue
+}
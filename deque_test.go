@@ -0,0 +1,108 @@
+package c3
+
+import "testing"
+
+func TestDequePushPop(t *testing.T) {
+	d := NewDeque()
+	for n := 0; n < 10; n++ {
+		d.PushBack(n)
+		d.PushFront(-n - 1)
+	}
+	assert(t, 20, d.Len(), "d.Len()")
+
+	front, _ := d.PeekFront()
+	assert(t, -10, front, "d.PeekFront()")
+	back, _ := d.PeekBack()
+	assert(t, 9, back, "d.PeekBack()")
+
+	for n := 9; n >= 0; n-- {
+		item, ok := d.PopBack()
+		assertb(t, true, ok, "ok")
+		assert(t, n, item, "d.PopBack()")
+		item, ok = d.PopFront()
+		assertb(t, true, ok, "ok")
+		assert(t, -n-1, item, "d.PopFront()")
+	}
+
+	item, ok := d.PopFront()
+	assertb(t, false, ok, "ok")
+	assert(t, nil, item, "item")
+	_, ok = d.PopBack()
+	assertb(t, false, ok, "ok")
+}
+
+func TestDequeIndexable(t *testing.T) {
+	d := NewDeque()
+	// wrap the ring buffer around
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	d.PushBack(3)
+
+	for n := 0; n < 4; n++ {
+		item, ok := d.Get(n)
+		assertb(t, true, ok, "ok")
+		assert(t, n, item, "d.Get(n)")
+	}
+	_, ok := d.Get(5)
+	assertb(t, false, ok, "ok")
+
+	assertIndexOf(t, d, 3, 3)
+	index, ok := d.LastIndexOf(3)
+	assertb(t, true, ok, "ok")
+	assert(t, 4, index, "index")
+	assertContains(t, d, 4, false)
+
+	index = 0
+	for i := d.Iterator(); i.MoveNext(); index++ {
+		item, _ := d.Get(index)
+		assert(t, item, i.Value(), "i.Value()")
+	}
+	assert(t, 5, index, "iterations")
+}
+
+func TestDequeConsumers(t *testing.T) {
+	d := ToDeque(Range(0, 5))
+	c := d.FrontConsumer()
+	c.MoveNext()
+	assert(t, 0, c.Value(), "c.Value()")
+
+	c = d.BackConsumer()
+	c.MoveNext()
+	assert(t, 5, c.Value(), "c.Value()")
+
+	count := 0
+	for c := d.BackConsumer(); c.MoveNext(); {
+		count++
+	}
+	assert(t, 4, count, "remaining items")
+	assert(t, 0, d.Len(), "d.Len()")
+}
+
+func TestDequeConcurrentModification(t *testing.T) {
+	d := DequeOf(1, 2, 3)
+	defer func() {
+		if recover() == nil {
+			fail(t, "expected a panic")
+		}
+	}()
+	for i := d.Iterator(); i.MoveNext(); {
+		d.PopFront()
+	}
+}
+
+func BenchmarkDequePushPop1000(b *testing.B) {
+	value := wrap(1)
+	d := NewDeque()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			d.PushBack(value)
+		}
+		for n := 0; n < 1000; n++ {
+			d.PopFront()
+		}
+	}
+}
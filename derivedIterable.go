@@ -0,0 +1,13 @@
+package c3
+
+// derivedIterable computes its items from the items of a source,
+// with a Generate function that reads the source Iterator.
+type derivedIterable struct {
+	items Iterable
+	g     func(source Iterator) Generate
+}
+
+func (i *derivedIterable) Iterator() Iterator {
+	source := i.items.Iterator()
+	return &derivedIterator{source, i.g(source), defaultElementValue}
+}
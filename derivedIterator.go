@@ -0,0 +1,31 @@
+package c3
+
+// derivedIterator forwards Close and Err to its source Iterator.
+type derivedIterator struct {
+	source Iterator
+	g      Generate
+	value  interface{}
+}
+
+func (i *derivedIterator) MoveNext() bool {
+	value, ok := i.g()
+	if ok {
+		i.value = value
+		return true
+	}
+	i.value = defaultElementValue
+	return false
+}
+
+func (i *derivedIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *derivedIterator) Close() {
+	Close(i.source)
+	i.value = defaultElementValue
+}
+
+func (i *derivedIterator) Err() error {
+	return Err(i.source)
+}
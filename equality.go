@@ -0,0 +1,61 @@
+package c3
+
+import "hash/maphash"
+import "strings"
+
+var (
+	// DefaultEquality uses Equatable.Equals and Equatable.Hashcode for
+	// Equatable items, and == and the builtin map hash for all other items.
+	// It uses maphash.Comparable, which requires go 1.24.
+	DefaultEquality Equality = &defaultEquality{maphash.MakeSeed()}
+	// IgnoreCase compares strings case-insensitively.
+	IgnoreCase Equality = &ignoreCaseEquality{maphash.MakeSeed()}
+)
+
+// MakeEquality converts the equals and hashcode functions into an Equality.
+func MakeEquality(equals func(a, b interface{}) bool, hashcode func(item interface{}) int) Equality {
+	return &funcEquality{equals, hashcode}
+}
+
+type funcEquality struct {
+	equals   func(a, b interface{}) bool
+	hashcode func(item interface{}) int
+}
+
+func (e *funcEquality) Equals(a, b interface{}) bool {
+	return e.equals(a, b)
+}
+
+func (e *funcEquality) Hashcode(item interface{}) int {
+	return e.hashcode(item)
+}
+
+type defaultEquality struct {
+	seed maphash.Seed
+}
+
+func (e *defaultEquality) Equals(a, b interface{}) bool {
+	if x, ok := a.(Equatable); ok {
+		return x.Equals(b)
+	}
+	return a == b
+}
+
+func (e *defaultEquality) Hashcode(item interface{}) int {
+	if x, ok := item.(Equatable); ok {
+		return x.Hashcode()
+	}
+	return int(maphash.Comparable(e.seed, item))
+}
+
+type ignoreCaseEquality struct {
+	seed maphash.Seed
+}
+
+func (e *ignoreCaseEquality) Equals(a, b interface{}) bool {
+	return strings.EqualFold(a.(string), b.(string))
+}
+
+func (e *ignoreCaseEquality) Hashcode(item interface{}) int {
+	return int(maphash.String(e.seed, strings.ToLower(strings.ToUpper(item.(string)))))
+}
@@ -0,0 +1,97 @@
+package c3
+
+import (
+	"reflect"
+	"testing"
+)
+
+type point struct {
+	x, y int
+}
+
+func (p point) Equals(other interface{}) bool {
+	o, ok := other.(point)
+	return ok && p.x == o.x
+}
+
+func (p point) Hashcode() int {
+	return p.x
+}
+
+type tagged struct {
+	name string
+	tags []string
+}
+
+var taggedEquality = MakeEquality(
+	func(a, b interface{}) bool { return reflect.DeepEqual(a, b) },
+	func(item interface{}) int { return len(item.(tagged).name) },
+)
+
+func TestSetIgnoreCase(t *testing.T) {
+	s := NewSetWith(IgnoreCase)
+	assertb(t, true, s.Add("Hello"), `s.Add("Hello")`)
+	assertb(t, false, s.Add("HELLO"), `s.Add("HELLO")`)
+	assertb(t, true, s.Contains("hello"), `s.Contains("hello")`)
+	assert(t, 1, s.Len(), "s.Len()")
+
+	assertb(t, true, s.Delete("hELLO"), `s.Delete("hELLO")`)
+	assert(t, 0, s.Len(), "s.Len()")
+}
+
+func TestSetOfUncomparableItems(t *testing.T) {
+	s := NewSetWith(taggedEquality)
+	s.Add(tagged{"a", []string{"x"}})
+	s.Add(tagged{"a", []string{"x"}})
+	s.Add(tagged{"a", []string{"y"}})
+	s.Add(tagged{"b", []string{"x"}})
+	assert(t, 3, s.Len(), "s.Len()")
+	assertb(t, true, s.Contains(tagged{"a", []string{"y"}}), "s.Contains(...)")
+	assert(t, 3, len(ToSlice(s)), "len(ToSlice(s))")
+}
+
+func TestSetOfEquatableItems(t *testing.T) {
+	a := NewSetWith(nil)
+	a.Add(point{1, 1})
+	a.Add(point{1, 2})
+	a.Add(point{2, 2})
+	assert(t, 2, a.Len(), "a.Len()")
+
+	b := NewSetWith(nil)
+	b.Add(point{2, 3})
+	b.Add(point{3, 3})
+	assert(t, 3, a.Union(b).Len(), "a.Union(b).Len()")
+	assert(t, 1, a.Intersection(b).Len(), "a.Intersection(b).Len()")
+	assert(t, 1, a.Difference(b).Len(), "a.Difference(b).Len()")
+	assert(t, 2, a.SymmetricDifference(b).Len(), "a.SymmetricDifference(b).Len()")
+}
+
+func TestListWithEquality(t *testing.T) {
+	l := NewListWith(IgnoreCase)
+	l.Add("a")
+	l.Add("B")
+	l.Add("b")
+	assertIndexOf(t, l, "b", 1)
+	index, ok := l.LastIndexOf("B")
+	assertb(t, true, ok, "ok")
+	assert(t, 2, index, "index")
+	assertb(t, true, l.Delete("A"), `l.Delete("A")`)
+	assert(t, 2, l.Len(), "l.Len()")
+}
+
+func TestDistinctBy(t *testing.T) {
+	q := QueryOf("a", "A", "b", "B", "c").DistinctBy(IgnoreCase)
+	assert(t, 3, q.Count(), "q.Count()")
+	// the query can be run more than once
+	assert(t, 3, q.Count(), "q.Count()")
+
+	q = QueryOf(point{1, 1}, point{1, 2}, point{2, 1}).DistinctBy(nil)
+	assert(t, 2, q.Count(), "q.Count()")
+}
+
+func TestContainsBy(t *testing.T) {
+	q := QueryOf("a", "b")
+	assertb(t, false, q.Contains("B"), `q.Contains("B")`)
+	assertb(t, true, q.ContainsBy("B", IgnoreCase), `q.ContainsBy("B", IgnoreCase)`)
+	assertb(t, true, QueryOf(point{1, 1}).ContainsBy(point{1, 2}, nil), "ContainsBy(point{1, 2}, nil)")
+}
@@ -0,0 +1,34 @@
+package c3
+
+// errorIterator iterates over the items, unless err is not nil.
+// It is used for the results of operators that read all items of their
+// source first, so the error of the source is reported by Err.
+type errorIterator struct {
+	items Iterator
+	err   error
+}
+
+func (i *errorIterator) MoveNext() bool {
+	if i.err != nil {
+		return false
+	}
+	return i.items.MoveNext()
+}
+
+func (i *errorIterator) Value() interface{} {
+	if i.err != nil {
+		return defaultElementValue
+	}
+	return i.items.Value()
+}
+
+func (i *errorIterator) Close() {
+	Close(i.items)
+}
+
+func (i *errorIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return Err(i.items)
+}
@@ -0,0 +1,101 @@
+package c3
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errFive = errors.New("five")
+
+// failAtFive returns a query over 1..10 that fails at item 5
+func failAtFive() *Q {
+	return NewQuery(Range(1, 10)).SelectE(func(v interface{}) (interface{}, error) {
+		if v.(int) == 5 {
+			return nil, errFive
+		}
+		return v, nil
+	})
+}
+
+func TestSelectE(t *testing.T) {
+	items, err := failAtFive().ToSliceE()
+	assert(t, errFive, err, "err")
+	assert(t, 4, len(items), "len(items)")
+
+	items, err = NewQuery(Range(1, 10)).SelectE(func(v interface{}) (interface{}, error) {
+		return v.(int) * 2, nil
+	}).ToSliceE()
+	assert(t, nil, err, "err")
+	assert(t, 10, len(items), "len(items)")
+}
+
+func TestWhereE(t *testing.T) {
+	l, err := NewQuery(Range(1, 10)).WhereE(func(v interface{}) (bool, error) {
+		if v.(int) == 7 {
+			return false, errFive
+		}
+		return v.(int)%2 == 0, nil
+	}).ToListE()
+	assert(t, errFive, err, "err")
+	assert(t, 3, l.Len(), "l.Len()")
+}
+
+func TestForE(t *testing.T) {
+	count := 0
+	err := NewQuery(Range(1, 10)).ForE(func(v interface{}) error {
+		if count++; count == 3 {
+			return errFive
+		}
+		return nil
+	})
+	assert(t, errFive, err, "err")
+	assert(t, 3, count, "count")
+	assert(t, errFive, failAtFive().ForE(func(v interface{}) error { return nil }), "ForE()")
+}
+
+func TestOperatorsPropagateErr(t *testing.T) {
+	byValue := func(v interface{}) interface{} { return v }
+	compare := func(a, b interface{}) int { return a.(int) - b.(int) }
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	queries := map[string]*Q{
+		"Where":       failAtFive().Where(func(v interface{}) bool { return true }),
+		"Select":      failAtFive().Select(byValue),
+		"SelectMany":  NewQuery(Range(1, 2)).SelectMany(func(v interface{}) Iterable { return failAtFive() }),
+		"Concat":      failAtFive().Concat(Range(1, 3)),
+		"Take":        failAtFive().Take(8),
+		"Skip":        failAtFive().Skip(1),
+		"Distinct":    failAtFive().Distinct(),
+		"DistinctBy":  failAtFive().DistinctBy(nil),
+		"Shuffle":     failAtFive().Shuffle(),
+		"Sort":        failAtFive().Sort(less),
+		"SortStable":  failAtFive().SortStable(less),
+		"OrderBy":     &failAtFive().OrderBy(byValue, compare).ThenBy(byValue, compare).Q,
+		"GroupBy":     failAtFive().GroupBy(byValue),
+		"Join":        NewQuery(Range(1, 3)).Join(failAtFive(), byValue, byValue, func(o, i interface{}) interface{} { return o }),
+		"Parallel":    &failAtFive().Parallel(2).AsOrdered().Select(byValue).Q,
+		"WithContext": failAtFive().WithContext(context.Background()),
+	}
+	for name, q := range queries {
+		if _, err := q.ToSliceE(); err != errFive {
+			failf(t, "%v: expected %v, got %v", name, errFive, err)
+		}
+	}
+
+	noop := func(v interface{}) error { return nil }
+	actions := map[string]error{
+		"GoWait":         failAtFive().GoWait(2, noop),
+		"GoBufferedWait": failAtFive().GoBufferedWait(2, 4, noop),
+	}
+	for name, err := range actions {
+		if !errors.Is(err, errFive) {
+			failf(t, "%v: expected %v, got %v", name, errFive, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewQuery(Range(1, 10)).WithContext(ctx).GoWait(2, noop); !errors.Is(err, context.Canceled) {
+		failf(t, "GoWait with a cancelled context: expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,181 @@
+package c3
+
+import "sync"
+
+// fanOut distributes the items of a single source Iterator over several streams.
+// Every stream has its own buffer of at most size items. A stream that needs
+// a new item pulls it from the source and routes it to the buffers,
+// but waits while a buffer that the item goes to is full, so streams that
+// diverge more than size items must be iterated on separate goroutines.
+type fanOut struct {
+	mu         sync.Mutex
+	cond       sync.Cond
+	source     Iterable
+	i          Iterator
+	route      func(item interface{}) int
+	size       int
+	buffers    [][]interface{}
+	started    []bool
+	closed     []bool
+	pending    interface{}
+	target     int
+	hasPending bool
+	pulling    bool
+	done       bool
+	err        error
+}
+
+// broadcastToAll is the route of items that go to every stream.
+const broadcastToAll = -1
+
+// newFanOut creates the streams of a fanOut. route returns the index of
+// the stream of an item, broadcastToAll, or any other value to drop the item.
+func newFanOut(source Iterable, streams, size int, route func(item interface{}) int) []*Q {
+	if size < 1 {
+		size = 1
+	}
+	f := &fanOut{
+		source:  source,
+		route:   route,
+		size:    size,
+		buffers: make([][]interface{}, streams),
+		started: make([]bool, streams),
+		closed:  make([]bool, streams),
+	}
+	f.cond.L = &f.mu
+	results := make([]*Q, streams)
+	for n := range results {
+		results[n] = NewQuery(&fanOutIterable{f, n})
+	}
+	return results
+}
+
+func (f *fanOut) start(stream int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.started[stream] {
+		panic("Fan-out stream iterated more than once")
+	}
+	f.started[stream] = true
+}
+
+// next returns the next item of the stream and true,
+// or nil and false if there are no more items.
+func (f *fanOut) next(stream int) (interface{}, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for {
+		if buffer := f.buffers[stream]; len(buffer) > 0 {
+			item := buffer[0]
+			buffer[0] = defaultElementValue
+			f.buffers[stream] = buffer[1:]
+			f.cond.Broadcast()
+			return item, true
+		}
+		if f.done || f.closed[stream] {
+			return defaultElementValue, false
+		}
+		if f.pulling {
+			f.cond.Wait()
+			continue
+		}
+		if !f.hasPending {
+			f.pull()
+			continue
+		}
+		if !f.fits() {
+			f.cond.Wait()
+			continue
+		}
+		for n := range f.buffers {
+			if f.receives(n) {
+				f.buffers[n] = append(f.buffers[n], f.pending)
+			}
+		}
+		f.pending, f.hasPending = defaultElementValue, false
+	}
+}
+
+// receives returns true if the pending item goes to the stream.
+func (f *fanOut) receives(stream int) bool {
+	return !f.closed[stream] && (f.target == broadcastToAll || f.target == stream)
+}
+
+// fits returns true if the pending item fits in the buffers of its streams.
+func (f *fanOut) fits() bool {
+	for n, buffer := range f.buffers {
+		if f.receives(n) && len(buffer) >= f.size {
+			return false
+		}
+	}
+	return true
+}
+
+// pull moves the source to the next item without holding the lock,
+// and makes it the pending item.
+func (f *fanOut) pull() {
+	f.pulling = true
+	f.mu.Unlock()
+	ok := false
+	defer func() {
+		f.mu.Lock()
+		f.pulling = false
+		if !ok || !f.open() {
+			f.finish()
+		}
+		f.cond.Broadcast()
+	}()
+
+	if f.i == nil {
+		f.i = f.source.Iterator()
+	}
+	if ok = f.i.MoveNext(); !ok {
+		return
+	}
+	item := f.i.Value()
+	target := f.route(item)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending, f.target, f.hasPending = item, target, true
+}
+
+// finish stops pulling from the source, the lock must be held.
+func (f *fanOut) finish() {
+	if f.done {
+		return
+	}
+	f.done = true
+	if f.i != nil {
+		f.err = Err(f.i)
+		Close(f.i)
+	}
+}
+
+// close detaches the stream, the source is closed when all streams are closed.
+func (f *fanOut) close(stream int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed[stream] = true
+	f.buffers[stream] = nil
+	if !f.open() && !f.pulling {
+		f.finish()
+	}
+	f.cond.Broadcast()
+}
+
+// open returns true if any stream is still open, the lock must be held.
+func (f *fanOut) open() bool {
+	for _, closed := range f.closed {
+		if !closed {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fanOut) error() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
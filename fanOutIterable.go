@@ -0,0 +1,12 @@
+package c3
+
+// fanOutIterable is a single stream of a fanOut.
+type fanOutIterable struct {
+	f      *fanOut
+	stream int
+}
+
+func (s *fanOutIterable) Iterator() Iterator {
+	s.f.start(s.stream)
+	return &fanOutIterator{s.f, s.stream, defaultElementValue}
+}
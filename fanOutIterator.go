@@ -0,0 +1,39 @@
+package c3
+
+type fanOutIterator struct {
+	f      *fanOut
+	stream int
+	value  interface{}
+}
+
+func (i *fanOutIterator) MoveNext() bool {
+	if i.f == nil {
+		return false
+	}
+	value, ok := i.f.next(i.stream)
+	if !ok {
+		i.value = defaultElementValue
+		return false
+	}
+	i.value = value
+	return true
+}
+
+func (i *fanOutIterator) Value() interface{} {
+	return i.value
+}
+
+// Close detaches the stream, so it no longer holds back the other streams.
+func (i *fanOutIterator) Close() {
+	if i.f != nil {
+		i.f.close(i.stream)
+	}
+	i.value = defaultElementValue
+}
+
+func (i *fanOutIterator) Err() error {
+	if i.f == nil {
+		return nil
+	}
+	return i.f.error()
+}
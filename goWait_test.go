@@ -0,0 +1,45 @@
+package c3
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoWaitCompletes(t *testing.T) {
+	var sum int64
+	err := NewQuery(Range(1, 100)).GoWait(4, func(v interface{}) error {
+		time.Sleep(time.Microsecond)
+		atomic.AddInt64(&sum, int64(v.(int)))
+		return nil
+	})
+	assertb(t, true, err == nil, "err == nil")
+	// every action has completed when GoWait returns
+	assert(t, int64(5050), atomic.LoadInt64(&sum), "sum")
+}
+
+func TestGoBufferedWaitErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	var count int64
+	err := GoBufferedWait(Range(1, 10), 3, 5, func(v interface{}) error {
+		atomic.AddInt64(&count, 1)
+		switch {
+		case v.(int) == 4:
+			panic("four")
+		case v.(int)%2 == 1:
+			return errOdd
+		}
+		return nil
+	})
+	assert(t, int64(10), atomic.LoadInt64(&count), "count")
+	assertb(t, true, errors.Is(err, errOdd), "errors.Is(err, errOdd)")
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		fail(t, "expected a PanicError")
+	}
+	assert(t, 4, panicErr.Item, "panicErr.Item")
+	assert(t, "four", panicErr.Value, "panicErr.Value")
+	assert(t, 6, len(err.(interface{ Unwrap() []error }).Unwrap()), "number of errors")
+}
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ReSc/c3"
+)
+
+// a small road network, with the vertices on a line at their x coordinate
+func roads() *Graph {
+	g := NewUndirected()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 4)
+	g.AddEdge(0, 3, 3)
+	g.AddEdge(3, 2, 1)
+	g.AddEdge(2, 5, 3)
+	g.AddEdge(1, 5, 9)
+	g.AddVertex(9)
+	return g
+}
+
+func TestSearch(t *testing.T) {
+	g := NewDirected()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 1)
+	g.AddEdge("d", "a", 1)
+	g.AddVertex("e")
+	assertItems(t, BreadthFirst(g, "a"), "a", "b", "c", "d")
+	assertItems(t, DepthFirst(g, "a"), "a", "b", "d", "c")
+	assertItems(t, BreadthFirst(g, "e"), "e")
+}
+
+func TestDijkstra(t *testing.T) {
+	p := Dijkstra(roads(), 0)
+	expected := map[int]float64{0: 0, 1: 1, 2: 4, 3: 3, 5: 7}
+	for v, d := range expected {
+		if actual, ok := p.Distance(v); !ok || actual != d {
+			t.Errorf("Expected distance %v to %v, got %v", d, v, actual)
+		}
+	}
+	if d, ok := p.Distance(9); ok || !math.IsInf(d, 1) {
+		t.Errorf("Expected 9 to be unreachable, got %v", d)
+	}
+	path, _ := p.PathTo(5)
+	assertItems(t, path, 0, 3, 2, 5)
+	if _, ok := p.PathTo(9); ok {
+		t.Error("PathTo an unreachable vertex succeeded")
+	}
+}
+
+func TestDijkstraNegativeWeight(t *testing.T) {
+	g := NewDirected()
+	g.AddEdge(1, 2, -1)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a negative weight panic")
+		}
+	}()
+	Dijkstra(g, 1)
+}
+
+func TestAStar(t *testing.T) {
+	// the remaining distance on the line is a lower bound of the path length
+	heuristic := func(v interface{}) float64 {
+		return math.Max(0, 5-float64(v.(int)))
+	}
+	path, d, ok := AStar(roads(), 0, 5, heuristic)
+	if !ok || d != 7 {
+		t.Errorf("Expected length 7, got %v", d)
+	}
+	assertItems(t, path, 0, 3, 2, 5)
+	if _, _, ok := AStar(roads(), 0, 9, heuristic); ok {
+		t.Error("AStar to an unreachable vertex succeeded")
+	}
+}
+
+func TestPrim(t *testing.T) {
+	forest := Prim(roads())
+	// the tree of the first component, the isolated vertex 9 has no edges
+	if forest.Len() != 4 {
+		t.Fatalf("Expected 4 edges, got %v", forest.Len())
+	}
+	total := 0.0
+	for i := forest.Iterator(); i.MoveNext(); {
+		total += i.Value().(Edge).Weight
+	}
+	if total != 8 {
+		t.Errorf("Expected weight 8, got %v", total)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected()
+	g.AddEdge("shirt", "tie", 0)
+	g.AddEdge("tie", "jacket", 0)
+	g.AddEdge("trousers", "shoes", 0)
+	g.AddEdge("trousers", "belt", 0)
+	g.AddEdge("belt", "jacket", 0)
+	g.AddEdge("socks", "shoes", 0)
+	sorted, ok := TopologicalSort(g)
+	if !ok {
+		t.Fatal("TopologicalSort failed")
+	}
+	assertItems(t, sorted, "shirt", "trousers", "socks", "tie", "belt", "shoes", "jacket")
+
+	g.AddEdge("jacket", "shirt", 0)
+	if _, ok := TopologicalSort(g); ok {
+		t.Error("TopologicalSort of a cyclic graph succeeded")
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewDirected()
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(3, 2, 0)
+	g.AddEdge(4, 5, 0)
+	g.AddVertex(6)
+	components := ConnectedComponents(g)
+	if components.Len() != 3 {
+		t.Fatalf("Expected 3 components, got %v", components.Len())
+	}
+	first, _ := components.Get(0)
+	assertItems(t, first.(c3.List), 1, 2, 3)
+	second, _ := components.Get(1)
+	assertItems(t, second.(c3.List), 4, 5)
+	third, _ := components.Get(2)
+	assertItems(t, third.(c3.List), 6)
+}
@@ -0,0 +1,208 @@
+// Package graph provides a weighted graph and graph algorithms.
+//
+// The vertices of a graph can be any comparable value, the edges have a
+// float64 weight. The adjacency of the graph and the results of the
+// traversals are exposed as c3 Iterables, so they can be queried with c3.NewQuery.
+//
+// The algorithms provided:
+//   - BreadthFirst and DepthFirst: lazy traversals from a start vertex.
+//   - Dijkstra and AStar: shortest paths with non-negative edge weights.
+//   - Prim: a minimum spanning forest of an undirected graph.
+//   - TopologicalSort: an ordering of the vertices of a directed acyclic graph.
+//   - ConnectedComponents: the (weakly) connected components of a graph.
+//
+// Dijkstra, AStar and Prim use a heap.FibHeap and its DecreaseKey.
+package graph
+
+import "github.com/ReSc/c3"
+
+// Edge is a weighted edge from one vertex to another.
+type Edge struct {
+	From, To interface{}
+	Weight   float64
+}
+
+// Graph is a directed or undirected weighted graph.
+// An undirected graph stores every edge in both directions.
+// The vertices and edges are iterated in the order in which they were added.
+type Graph struct {
+	directed bool
+	version  int
+	edges    int
+	vertices []*vertex
+	index    map[interface{}]int
+}
+
+type vertex struct {
+	value interface{}
+	// the outgoing edges, in the order in which they were added
+	out []Edge
+	// the index in out of the edge to a vertex
+	edges map[interface{}]int
+}
+
+// NewDirected creates a new, empty directed graph.
+func NewDirected() *Graph {
+	return &Graph{directed: true, index: make(map[interface{}]int)}
+}
+
+// NewUndirected creates a new, empty undirected graph.
+func NewUndirected() *Graph {
+	return &Graph{index: make(map[interface{}]int)}
+}
+
+// Directed returns true if the graph is directed.
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+// VertexCount returns the number of vertices.
+func (g *Graph) VertexCount() int {
+	return len(g.vertices)
+}
+
+// EdgeCount returns the number of edges, an undirected edge is counted once.
+func (g *Graph) EdgeCount() int {
+	return g.edges
+}
+
+// AddVertex adds the vertex to the graph,
+// returns true if the graph was modified,
+// false if the vertex was already in the graph.
+func (g *Graph) AddVertex(v interface{}) bool {
+	if _, ok := g.index[v]; ok {
+		return false
+	}
+	g.index[v] = len(g.vertices)
+	g.vertices = append(g.vertices, &vertex{value: v, edges: make(map[interface{}]int)})
+	g.version++
+	return true
+}
+
+// ContainsVertex returns true if the vertex is in the graph.
+func (g *Graph) ContainsVertex(v interface{}) bool {
+	_, ok := g.index[v]
+	return ok
+}
+
+// AddEdge adds an edge with the weight from one vertex to another, and adds
+// the vertices if they are not in the graph. If the edge is already in the
+// graph its weight is updated. Returns true if a new edge was added.
+func (g *Graph) AddEdge(from, to interface{}, weight float64) bool {
+	g.AddVertex(from)
+	g.AddVertex(to)
+	added := g.vertex(from).setEdge(Edge{from, to, weight})
+	if !g.directed && from != to {
+		g.vertex(to).setEdge(Edge{to, from, weight})
+	}
+	if added {
+		g.edges++
+	}
+	g.version++
+	return added
+}
+
+// Edge returns the edge from one vertex to another and true,
+// or an empty Edge and false if there is no such edge.
+func (g *Graph) Edge(from, to interface{}) (Edge, bool) {
+	if i, ok := g.index[from]; ok {
+		if e, ok := g.vertices[i].edges[to]; ok {
+			return g.vertices[i].out[e], true
+		}
+	}
+	return Edge{}, false
+}
+
+// Vertices returns an Iterable over the vertices of the graph.
+func (g *Graph) Vertices() c3.Iterable {
+	return g.iterable(func(yield func(interface{})) {
+		for _, v := range g.vertices {
+			yield(v.value)
+		}
+	})
+}
+
+// Edges returns an Iterable over the edges of the graph.
+// An undirected edge is yielded once, from the vertex that was added first.
+func (g *Graph) Edges() c3.Iterable {
+	return g.iterable(func(yield func(interface{})) {
+		for i, v := range g.vertices {
+			for _, e := range v.out {
+				if g.directed || g.index[e.To] >= i {
+					yield(e)
+				}
+			}
+		}
+	})
+}
+
+// OutEdges returns an Iterable over the edges from the vertex.
+// Panics if the vertex is not in the graph.
+func (g *Graph) OutEdges(v interface{}) c3.Iterable {
+	from := g.vertex(v)
+	return g.iterable(func(yield func(interface{})) {
+		for _, e := range from.out {
+			yield(e)
+		}
+	})
+}
+
+// Neighbors returns an Iterable over the vertices that
+// the edges from the vertex lead to.
+// Panics if the vertex is not in the graph.
+func (g *Graph) Neighbors(v interface{}) c3.Iterable {
+	from := g.vertex(v)
+	return g.iterable(func(yield func(interface{})) {
+		for _, e := range from.out {
+			yield(e.To)
+		}
+	})
+}
+
+// iterable creates an Iterable over the items that the walk yields, when
+// the iterator is created. The iterator panics if the graph is modified.
+func (g *Graph) iterable(walk func(yield func(interface{}))) c3.Iterable {
+	return c3.MakeIterable(func() c3.Generate {
+		items := make([]interface{}, 0)
+		walk(func(item interface{}) {
+			items = append(items, item)
+		})
+		version := g.version
+		return func() (interface{}, bool) {
+			if g.version != version {
+				panic("Concurrent modification detected")
+			}
+			if len(items) == 0 {
+				return nil, false
+			}
+			item := items[0]
+			items = items[1:]
+			return item, true
+		}
+	})
+}
+
+// vertex returns the vertex, panics if it is not in the graph.
+func (g *Graph) vertex(v interface{}) *vertex {
+	return g.vertices[g.indexOf(v)]
+}
+
+// indexOf returns the index of the vertex, panics if it is not in the graph.
+func (g *Graph) indexOf(v interface{}) int {
+	i, ok := g.index[v]
+	if !ok {
+		panic("Vertex is not in the graph")
+	}
+	return i
+}
+
+// setEdge adds or updates the edge, returns true if the edge was added.
+func (v *vertex) setEdge(e Edge) bool {
+	if i, ok := v.edges[e.To]; ok {
+		v.out[i] = e
+		return false
+	}
+	v.edges[e.To] = len(v.out)
+	v.out = append(v.out, e)
+	return true
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/ReSc/c3"
+)
+
+func TestGraphDirected(t *testing.T) {
+	g := NewDirected()
+	if !g.AddEdge("a", "b", 1) || !g.AddEdge("a", "c", 2) || !g.AddEdge("c", "b", 3) {
+		t.Error("AddEdge failed")
+	}
+	if g.AddEdge("a", "b", 5) {
+		t.Error("AddEdge of an existing edge returned true")
+	}
+	if g.AddVertex("a") || !g.AddVertex("d") {
+		t.Error("AddVertex failed")
+	}
+	if g.VertexCount() != 4 || g.EdgeCount() != 3 {
+		t.Errorf("Expected 4 vertices and 3 edges, got %v and %v", g.VertexCount(), g.EdgeCount())
+	}
+	if e, ok := g.Edge("a", "b"); !ok || e.Weight != 5 {
+		t.Errorf("Expected weight 5, got %v", e)
+	}
+	if _, ok := g.Edge("b", "a"); ok {
+		t.Error("Edge b-a found in a directed graph")
+	}
+	assertItems(t, g.Vertices(), "a", "b", "c", "d")
+	assertItems(t, g.Neighbors("a"), "b", "c")
+	assertItems(t, g.OutEdges("c"), Edge{"c", "b", 3})
+	assertItems(t, g.Neighbors("d"))
+	if c3.NewQuery(g.Edges()).Count() != 3 {
+		t.Error("Expected 3 edges")
+	}
+}
+
+func TestGraphUndirected(t *testing.T) {
+	g := NewUndirected()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 3, 1)
+	if g.EdgeCount() != 3 {
+		t.Errorf("Expected 3 edges, got %v", g.EdgeCount())
+	}
+	assertItems(t, g.Neighbors(2), 1, 3)
+	assertItems(t, g.Edges(), Edge{1, 2, 1}, Edge{2, 3, 1}, Edge{3, 3, 1})
+}
+
+func TestGraphConcurrentModification(t *testing.T) {
+	g := NewDirected()
+	g.AddEdge(1, 2, 1)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a concurrent modification panic")
+		}
+	}()
+	for i := g.Vertices().Iterator(); i.MoveNext(); {
+		g.AddVertex(3)
+	}
+}
+
+// assertItems fails if the items are not the expected items, in order
+func assertItems(t *testing.T, items c3.Iterable, expected ...interface{}) {
+	t.Helper()
+	actual := c3.ToSlice(items)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package graph
+
+import "github.com/ReSc/c3"
+
+// TopologicalSort orders the vertices of the directed graph so that every
+// edge leads from a vertex to a later vertex. Vertices without an order
+// between them keep the order in which they were added.
+// Returns the List of ordered vertices and true, or nil and false if
+// the graph has a cycle. Panics if the graph is undirected.
+func TopologicalSort(g *Graph) (c3.List, bool) {
+	if !g.directed {
+		panic("TopologicalSort requires a directed graph")
+	}
+	incoming := make([]int, len(g.vertices))
+	for _, v := range g.vertices {
+		for _, e := range v.out {
+			incoming[g.index[e.To]]++
+		}
+	}
+	ready := make([]int, 0)
+	for i, n := range incoming {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+	sorted := c3.NewList()
+	for len(ready) > 0 {
+		v := g.vertices[ready[0]]
+		ready = ready[1:]
+		sorted.Add(v.value)
+		for _, e := range v.out {
+			to := g.index[e.To]
+			if incoming[to]--; incoming[to] == 0 {
+				ready = append(ready, to)
+			}
+		}
+	}
+	if sorted.Len() != len(g.vertices) {
+		return nil, false
+	}
+	return sorted, true
+}
+
+// ConnectedComponents returns a List with a List of vertices for every
+// connected component of the graph. The edges of a directed graph are treated
+// as undirected edges, so these are the weakly connected components.
+// The components and their vertices are in the order in which the vertices were added.
+func ConnectedComponents(g *Graph) c3.List {
+	// union find, with path halving
+	parent := make([]int, len(g.vertices))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for i, v := range g.vertices {
+		for _, e := range v.out {
+			a, b := find(i), find(g.index[e.To])
+			// the root is the vertex that was added first
+			if a < b {
+				parent[b] = a
+			} else {
+				parent[a] = b
+			}
+		}
+	}
+
+	components := c3.NewList()
+	index := make(map[int]c3.List)
+	for i, v := range g.vertices {
+		root := find(i)
+		component, ok := index[root]
+		if !ok {
+			component = c3.NewList()
+			index[root] = component
+			components.Add(component)
+		}
+		component.Add(v.value)
+	}
+	return components
+}
@@ -0,0 +1,69 @@
+package graph
+
+import "github.com/ReSc/c3"
+
+// BreadthFirst returns an Iterable over the vertices that can be reached
+// from the start vertex, in breadth first order. The vertices are visited
+// lazily while iterating, the iterator panics if the graph is modified.
+// Panics if the start vertex is not in the graph.
+func BreadthFirst(g *Graph, start interface{}) c3.Iterable {
+	s := g.indexOf(start)
+	return c3.MakeIterable(func() c3.Generate {
+		visited := make([]bool, len(g.vertices))
+		visited[s] = true
+		queue := []int{s}
+		version := g.version
+		return func() (interface{}, bool) {
+			if g.version != version {
+				panic("Concurrent modification detected")
+			}
+			if len(queue) == 0 {
+				return nil, false
+			}
+			v := g.vertices[queue[0]]
+			queue = queue[1:]
+			for _, e := range v.out {
+				if to := g.index[e.To]; !visited[to] {
+					visited[to] = true
+					queue = append(queue, to)
+				}
+			}
+			return v.value, true
+		}
+	})
+}
+
+// DepthFirst returns an Iterable over the vertices that can be reached
+// from the start vertex, in depth first preorder. The vertices are visited
+// lazily while iterating, the iterator panics if the graph is modified.
+// Panics if the start vertex is not in the graph.
+func DepthFirst(g *Graph, start interface{}) c3.Iterable {
+	s := g.indexOf(start)
+	return c3.MakeIterable(func() c3.Generate {
+		visited := make([]bool, len(g.vertices))
+		stack := []int{s}
+		version := g.version
+		return func() (interface{}, bool) {
+			if g.version != version {
+				panic("Concurrent modification detected")
+			}
+			for len(stack) > 0 {
+				i := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if visited[i] {
+					continue
+				}
+				visited[i] = true
+				v := g.vertices[i]
+				// push in reverse, so the first edge is followed first
+				for n := len(v.out) - 1; n >= 0; n-- {
+					if to := g.index[v.out[n].To]; !visited[to] {
+						stack = append(stack, to)
+					}
+				}
+				return v.value, true
+			}
+			return nil, false
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/ReSc/c3"
+	"github.com/ReSc/c3/heap"
+)
+
+// ShortestPaths holds the shortest paths from a source vertex,
+// computed by Dijkstra.
+type ShortestPaths struct {
+	g      *Graph
+	source int
+	dist   []float64
+	prev   []int
+}
+
+// Source returns the source vertex of the paths.
+func (p *ShortestPaths) Source() interface{} {
+	return p.g.vertices[p.source].value
+}
+
+// Distance returns the length of the shortest path to the vertex and true,
+// or +Inf and false if the vertex can not be reached from the source.
+func (p *ShortestPaths) Distance(v interface{}) (float64, bool) {
+	i, ok := p.g.index[v]
+	if !ok || i >= len(p.dist) || math.IsInf(p.dist[i], 1) {
+		return math.Inf(1), false
+	}
+	return p.dist[i], true
+}
+
+// PathTo returns the vertices of the shortest path from the source to the vertex
+// and true, or nil and false if the vertex can not be reached from the source.
+func (p *ShortestPaths) PathTo(v interface{}) (c3.List, bool) {
+	if _, ok := p.Distance(v); !ok {
+		return nil, false
+	}
+	return p.g.path(p.prev, p.g.index[v]), true
+}
+
+// Dijkstra computes the shortest paths from the source vertex to all vertices
+// that can be reached from it.
+// Panics if the source is not in the graph, or if an edge has a negative weight.
+func Dijkstra(g *Graph, source interface{}) *ShortestPaths {
+	s := g.indexOf(source)
+	dist, prev := g.search(s, -1, nil)
+	return &ShortestPaths{g, s, dist, prev}
+}
+
+// AStar computes the shortest path from one vertex to another, guided by the
+// heuristic, which estimates the length of the shortest path from a vertex
+// to the target. The heuristic must never overestimate that length.
+// Returns the vertices of the path, its length and true,
+// or nil, +Inf and false if the target can not be reached.
+// Panics if a vertex is not in the graph, or if an edge has a negative weight.
+func AStar(g *Graph, from, to interface{}, heuristic func(v interface{}) float64) (c3.List, float64, bool) {
+	if heuristic == nil {
+		panic("heuristic parameter invalid")
+	}
+	s, t := g.indexOf(from), g.indexOf(to)
+	dist, prev := g.search(s, t, heuristic)
+	if math.IsInf(dist[t], 1) {
+		return nil, dist[t], false
+	}
+	return g.path(prev, t), dist[t], true
+}
+
+// search runs Dijkstra from vertex s, or A* if the heuristic is not nil.
+// The search stops when vertex t is reached, t = -1 searches all vertices.
+// Returns the distances and the previous vertex on the paths, -1 for none.
+func (g *Graph) search(s, t int, heuristic func(v interface{}) float64) ([]float64, []int) {
+	n := len(g.vertices)
+	dist := make([]float64, n)
+	prev := make([]int, n)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	estimate := func(i int) float64 {
+		if heuristic == nil {
+			return dist[i]
+		}
+		return dist[i] + heuristic(g.vertices[i].value)
+	}
+
+	h := heap.NewFibonacci[int]()
+	handles := make([]heap.Handle[float64, int], n)
+	dist[s] = 0
+	handles[s] = h.Insert(s, estimate(s))
+	for !h.IsEmpty() {
+		u, _ := h.DeleteMin()
+		if u == t {
+			break
+		}
+		for _, e := range g.vertices[u].out {
+			if e.Weight < 0 {
+				panic("Negative edge weights are not supported")
+			}
+			v := g.index[e.To]
+			if d := dist[u] + e.Weight; d < dist[v] {
+				dist[v] = d
+				prev[v] = u
+				if h.Contains(handles[v]) {
+					h.DecreaseKey(handles[v], estimate(v))
+				} else {
+					handles[v] = h.Insert(v, estimate(v))
+				}
+			}
+		}
+	}
+	return dist, prev
+}
+
+// path returns the vertices of the path to vertex t.
+func (g *Graph) path(prev []int, t int) c3.List {
+	path := c3.NewList()
+	for i := t; i >= 0; i = prev[i] {
+		path.InsertAt(0, g.vertices[i].value)
+	}
+	return path
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/ReSc/c3"
+	"github.com/ReSc/c3/heap"
+)
+
+// Prim computes a minimum spanning forest of the undirected graph,
+// i.e. a minimum spanning tree for every connected component.
+// Returns a List of the Edges of the forest.
+// Panics if the graph is directed.
+func Prim(g *Graph) c3.List {
+	if g.directed {
+		panic("Prim requires an undirected graph")
+	}
+	n := len(g.vertices)
+	// the cheapest known edge into a vertex, and its weight
+	best := make([]Edge, n)
+	weight := make([]float64, n)
+	done := make([]bool, n)
+	handles := make([]heap.Handle[float64, int], n)
+	for i := range weight {
+		weight[i] = math.Inf(1)
+	}
+
+	forest := c3.NewList()
+	h := heap.NewFibonacci[int]()
+	for root := range g.vertices {
+		if done[root] {
+			continue
+		}
+		handles[root] = h.Insert(root, 0)
+		for !h.IsEmpty() {
+			u, _ := h.DeleteMin()
+			done[u] = true
+			if u != root {
+				forest.Add(best[u])
+			}
+			for _, e := range g.vertices[u].out {
+				v := g.index[e.To]
+				if done[v] || e.Weight >= weight[v] {
+					continue
+				}
+				weight[v] = e.Weight
+				best[v] = e
+				if h.Contains(handles[v]) {
+					h.DecreaseKey(handles[v], e.Weight)
+				} else {
+					handles[v] = h.Insert(v, e.Weight)
+				}
+			}
+		}
+	}
+	return forest
+}
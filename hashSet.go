@@ -0,0 +1,135 @@
+package c3
+
+// hashSet is a Set that uses an Equality to compare the items.
+type hashSet struct {
+	version int
+	length  int
+	eq      Equality
+	buckets map[int][]interface{}
+}
+
+func (s *hashSet) indexOf(bucket []interface{}, item interface{}) int {
+	for index, x := range bucket {
+		if s.eq.Equals(x, item) {
+			return index
+		}
+	}
+	return -1
+}
+
+func (s *hashSet) Add(item interface{}) bool {
+	hash := s.eq.Hashcode(item)
+	bucket := s.buckets[hash]
+	if s.indexOf(bucket, item) >= 0 {
+		return false
+	}
+	s.buckets[hash] = append(bucket, item)
+	s.length++
+	s.version++
+	return true
+}
+
+func (s *hashSet) Contains(item interface{}) bool {
+	return s.indexOf(s.buckets[s.eq.Hashcode(item)], item) >= 0
+}
+
+func (s *hashSet) Delete(item interface{}) bool {
+	hash := s.eq.Hashcode(item)
+	bucket := s.buckets[hash]
+	index := s.indexOf(bucket, item)
+	if index < 0 {
+		return false
+	}
+	last := len(bucket) - 1
+	if last == 0 {
+		delete(s.buckets, hash)
+	} else {
+		bucket[index] = bucket[last]
+		bucket[last] = defaultElementValue
+		s.buckets[hash] = bucket[:last]
+	}
+	s.length--
+	s.version++
+	return true
+}
+
+func (s *hashSet) Clear() {
+	if s.length == 0 {
+		return
+	}
+	s.buckets = make(map[int][]interface{})
+	s.length = 0
+	s.version++
+}
+
+func (s *hashSet) Len() int {
+	return s.length
+}
+
+func (s *hashSet) Iterator() Iterator {
+	return MakeIterable(func() Generate {
+		items := make([]interface{}, 0, s.length)
+		for _, bucket := range s.buckets {
+			items = append(items, bucket...)
+		}
+		version := s.version
+		return func() (interface{}, bool) {
+			if s.version != version {
+				panic("Concurrent modification detected")
+			}
+			if len(items) == 0 {
+				return defaultElementValue, false
+			}
+
+			item := items[0]
+			items = items[1:]
+			return item, true
+		}
+	}).Iterator()
+}
+
+func (s *hashSet) Intersection(other Set) Set {
+	result := NewSetWith(s.eq)
+	for i := other.Iterator(); i.MoveNext(); {
+		if s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *hashSet) Difference(other Set) Set {
+	result := NewSetWith(s.eq)
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *hashSet) Union(other Set) Set {
+	result := NewSetWith(s.eq)
+	for i := s.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	return result
+}
+
+func (s *hashSet) SymmetricDifference(other Set) Set {
+	result := NewSetWith(s.eq)
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		if !s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
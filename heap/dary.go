@@ -0,0 +1,355 @@
+package heap
+
+/*
+DaryHeap implements an array based d-ary heap.
+
+Every node has at most d children, the children of the node at
+index i are at the indexes d*i+1 to d*i+d. Insert and DecreaseKey
+sift a node up in O(log n / log d), DeleteMin, Delete and IncreaseKey
+sift a node down in O(d log n / log d). A larger d makes the heap
+shallower, which helps when DecreaseKey is used a lot.
+The array layout makes small heaps fast, because there are
+no links to follow.
+
+Note that this implementation is not synchronized.
+*/
+type DaryHeap[K, V any] struct {
+	/* the heap ordered nodes, the minimum node is at index 0 */
+	nodes []*node[K, V]
+	/* the maximum number of children of a node */
+	d int
+	/* Orders the keys, the minimum node has the key that is ordered first. */
+	less func(a, b K) bool
+	/* Identifies the nodes of this heap. */
+	id *heapID
+}
+
+/*
+Creates a binary min-heap with float64 keys.
+*/
+func NewBinary[V any]() *DaryHeap[float64, V] {
+	return NewDaryFunc[float64, V](2, Less[float64])
+}
+
+/*
+Creates a binary max-heap with float64 keys.
+*/
+func NewBinaryMax[V any]() *DaryHeap[float64, V] {
+	return NewDaryFunc[float64, V](2, Greater[float64])
+}
+
+/*
+Creates a binary heap that orders the keys with the less function.
+See NewFibonacciFunc.
+*/
+func NewBinaryFunc[K, V any](less func(a, b K) bool) *DaryHeap[K, V] {
+	return NewDaryFunc[K, V](2, less)
+}
+
+/*
+Creates a d-ary min-heap with float64 keys.
+*/
+func NewDary[V any](d int) *DaryHeap[float64, V] {
+	return NewDaryFunc[float64, V](d, Less[float64])
+}
+
+/*
+Creates a d-ary max-heap with float64 keys.
+*/
+func NewDaryMax[V any](d int) *DaryHeap[float64, V] {
+	return NewDaryFunc[float64, V](d, Greater[float64])
+}
+
+/*
+Creates a d-ary heap that orders the keys with the less function.
+See NewFibonacciFunc.
+*/
+func NewDaryFunc[K, V any](d int, less func(a, b K) bool) *DaryHeap[K, V] {
+	if d < 2 {
+		panic("d must be at least 2")
+	}
+	if less == nil {
+		panic("less function invalid")
+	}
+	return &DaryHeap[K, V]{nil, d, less, &heapID{}}
+}
+
+/*
+Removes all elements from this heap.
+Handles to the removed elements are no longer valid.
+
+Running time: O(1)
+*/
+func (h *DaryHeap[K, V]) Clear() {
+	h.nodes = nil
+	h.id = &heapID{}
+}
+
+/*
+Returns the number of elements in the heap.
+
+Running time: O(1)
+*/
+func (h *DaryHeap[K, V]) Len() int {
+	return len(h.nodes)
+}
+
+/*
+Returns true if the heap is empty.
+
+Running time: O(1)
+*/
+func (h *DaryHeap[K, V]) IsEmpty() bool {
+	return len(h.nodes) == 0
+}
+
+/*
+Inserts a new element into the heap.
+
+Running time: O(log n)
+
+Returns the handle of the new element.
+*/
+func (h *DaryHeap[K, V]) Insert(value V, key K) Handle[K, V] {
+	n := newNode(h.id, value, key)
+	h.insert(n)
+	return Handle[K, V]{n}
+}
+
+/*
+Adds the node at the end of the array and sifts it up.
+*/
+func (h *DaryHeap[K, V]) insert(n *node[K, V]) {
+	n.index = len(h.nodes)
+	h.nodes = append(h.nodes, n)
+	h.up(n.index)
+}
+
+/*
+Returns the value with the minimum key and true,
+or the zero value and false if empty.
+
+Running time: O(1)
+*/
+func (h *DaryHeap[K, V]) Min() (V, bool) {
+	if len(h.nodes) == 0 {
+		var zero V
+		return zero, false
+	}
+	return h.nodes[0].value, true
+}
+
+/*
+Returns the handle of the element with the minimum key and true,
+or the zero Handle and false if empty.
+
+Running time: O(1)
+*/
+func (h *DaryHeap[K, V]) MinHandle() (Handle[K, V], bool) {
+	if len(h.nodes) == 0 {
+		return Handle[K, V]{}, false
+	}
+	return Handle[K, V]{h.nodes[0]}, true
+}
+
+/*
+Removes the element with the minimum key from the heap.
+
+Running time: O(d log n / log d)
+
+Returns the value with the minimum key and true, or the zero value and false if empty.
+*/
+func (h *DaryHeap[K, V]) DeleteMin() (V, bool) {
+	if len(h.nodes) == 0 {
+		var zero V
+		return zero, false
+	}
+	n := h.nodes[0]
+	h.extract(n)
+	return n.remove(), true
+}
+
+/*
+Deletes the element of the handle from the heap.
+
+Running time: O(d log n / log d)
+
+Returns true if the element was removed, false if it is not in the heap.
+*/
+func (h *DaryHeap[K, V]) Delete(handle Handle[K, V]) bool {
+	n, ok := handle.owner(h.id)
+	if ok {
+		h.extract(n)
+		n.remove()
+	}
+	return ok
+}
+
+/*
+Decreases the key of the element of the handle.
+
+Running time: O(log n / log d)
+
+Returns true if the key was decreased, false if the element is not
+in the heap or k is ordered after the current key.
+*/
+func (h *DaryHeap[K, V]) DecreaseKey(handle Handle[K, V], k K) bool {
+	n, ok := handle.owner(h.id)
+	if !ok || h.less(n.key, k) {
+		return false
+	}
+	n.key = k
+	h.up(n.index)
+	return true
+}
+
+/*
+Increases the key of the element of the handle.
+
+Running time: O(d log n / log d)
+
+Returns true if the key was increased, false if the element is not
+in the heap or k is ordered before the current key.
+*/
+func (h *DaryHeap[K, V]) IncreaseKey(handle Handle[K, V], k K) bool {
+	n, ok := handle.owner(h.id)
+	if !ok || h.less(k, n.key) {
+		return false
+	}
+	n.key = k
+	h.down(n.index)
+	return true
+}
+
+/*
+Returns the key of the element of the handle and true,
+or the zero key and false if the element is not in the heap.
+*/
+func (h *DaryHeap[K, V]) Key(handle Handle[K, V]) (K, bool) {
+	if n, ok := handle.owner(h.id); ok {
+		return n.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+/*
+Returns the value of the element of the handle and true,
+or the zero value and false if the element is not in the heap.
+*/
+func (h *DaryHeap[K, V]) Value(handle Handle[K, V]) (V, bool) {
+	if n, ok := handle.owner(h.id); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+/*
+Returns true if the element of the handle is in the heap.
+*/
+func (h *DaryHeap[K, V]) Contains(handle Handle[K, V]) bool {
+	_, ok := handle.owner(h.id)
+	return ok
+}
+
+/*
+Moves the elements of the other heap to this heap,
+the other heap is empty after the Join.
+If the other heap is a DaryHeap the handles of the moved elements
+refer to elements of this heap, otherwise they are no longer valid.
+
+Running time: O(n + m), or O(m log m) if other is not a DaryHeap
+*/
+func (h *DaryHeap[K, V]) Join(other Heap[K, V]) {
+	o, ok := other.(*DaryHeap[K, V])
+	if !ok {
+		moveAll[K, V](h, other)
+		return
+	}
+	if o == nil || o == h {
+		return
+	}
+	if len(o.nodes) > 0 {
+		for _, n := range o.nodes {
+			n.index = len(h.nodes)
+			h.nodes = append(h.nodes, n)
+		}
+		// restore the heap order bottom up
+		for i := (len(h.nodes) - 2) / h.d; i >= 0; i-- {
+			h.down(i)
+		}
+		// forward the nodes of o to h
+		o.id.joined = h.id
+	}
+	o.Clear()
+}
+
+/*
+Removes the node from the heap, without detaching it.
+The last node takes its place.
+*/
+func (h *DaryHeap[K, V]) extract(n *node[K, V]) {
+	i := n.index
+	last := len(h.nodes) - 1
+	moved := h.nodes[last]
+	h.nodes[last] = nil
+	h.nodes = h.nodes[:last]
+	if i != last {
+		// the moved node may belong above or below i
+		h.nodes[i] = moved
+		h.up(i)
+		h.down(moved.index)
+	}
+	n.reset()
+}
+
+/*
+Moves the node at index i up until its parent is not ordered after it.
+*/
+func (h *DaryHeap[K, V]) up(i int) {
+	n := h.nodes[i]
+	for i > 0 {
+		p := (i - 1) / h.d
+		if !h.less(n.key, h.nodes[p].key) {
+			break
+		}
+		h.nodes[i] = h.nodes[p]
+		h.nodes[i].index = i
+		i = p
+	}
+	h.nodes[i] = n
+	n.index = i
+}
+
+/*
+Moves the node at index i down until none of its children is ordered before it.
+*/
+func (h *DaryHeap[K, V]) down(i int) {
+	n := h.nodes[i]
+	length := len(h.nodes)
+	for {
+		first := i*h.d + 1
+		if first >= length {
+			break
+		}
+		last := first + h.d
+		if last > length {
+			last = length
+		}
+		min := first
+		for c := first + 1; c < last; c++ {
+			if h.less(h.nodes[c].key, h.nodes[min].key) {
+				min = c
+			}
+		}
+		if !h.less(h.nodes[min].key, n.key) {
+			break
+		}
+		h.nodes[i] = h.nodes[min]
+		h.nodes[i].index = i
+		i = min
+	}
+	h.nodes[i] = n
+	n.index = i
+}
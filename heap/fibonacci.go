@@ -1,7 +1,5 @@
 package heap
 
-import "math"
-
 /*
   Ported to Go from http://code.google.com/p/graphmaker/source/browse/core/src/com/bluemarsh/graphmaker/core/util/FibonacciHeap.java
 
@@ -33,6 +31,12 @@ import "math"
   of O(1). removeMin() and delete() have O(log n) amortized running
   times because they do the heap consolidation.
 
+  Insert returns a Handle to the new element, DecreaseKey, Delete
+  and Key use the handle to find the element in O(1).
+
+  The keys are ordered by a less function, so the heap can be a
+  min-heap, a max-heap or order composite keys, see NewFibonacciFunc.
+
   Note that this implementation is not synchronized.
   If multiple threads access a set concurrently, and at least one of the
   threads modifies the set, it must be synchronized externally.
@@ -41,30 +45,78 @@ import "math"
 
   author:  Nathan Fiedler
 */
-type FibHeap struct {
+type FibHeap[K, V any] struct {
 	/* Points to the minimum node in the heap. */
-	min *fibNode
-	/* Points to the head of the free nodes in the heap. */
-	free *fibNode
+	min *node[K, V]
 	/* Number of nodes in the heap. If the type is ever widened,
 	(e.g. changed to long) then recalcuate the maximum degree
 	value used in the consolidate() method. */
 	length int
+	/* Orders the keys, the minimum node has the key that is ordered first. */
+	less func(a, b K) bool
+	/* Identifies the nodes of this heap, see owns(). */
+	id *heapID
+	/* Incremented on every modification, to detect modifications while iterating. */
+	version int
+}
+
+func NewFibonacci[V any]() *FibHeap[float64, V] {
+	return NewFibonacciFunc[float64, V](Less[float64])
+}
+
+/*
+  Creates a max-heap with float64 keys, Min and DeleteMin
+  return the value with the largest key, DecreaseKey and
+  IncreaseKey move a value towards and away from the top.
+*/
+func NewFibonacciMax[V any]() *FibHeap[float64, V] {
+	return NewFibonacciFunc[float64, V](Greater[float64])
+}
+
+/*
+  Creates a heap that orders the keys with the less function.
+  The heap is a min-heap with respect to less: Min returns the value
+  with the key that is ordered first, and DecreaseKey accepts keys
+  that are not ordered after the current key.
+
+  Pass Greater to get a max-heap, or a function that compares
+  composite keys field by field.
+*/
+func NewFibonacciFunc[K, V any](less func(a, b K) bool) *FibHeap[K, V] {
+	if less == nil {
+		panic("less function invalid")
+	}
+	return &FibHeap[K, V]{nil, 0, less, &heapID{}, 0}
+}
+
+/*
+  Returns true if node a is ordered before node b.
+*/
+func (h *FibHeap[K, V]) lessNode(a, b *node[K, V]) bool {
+	return h.less(a.key, b.key)
 }
 
-func NewFibonacci() *FibHeap {
-	return &FibHeap{nil, nil, 0}
+/*
+  Returns the node of the handle and true if the node is in this heap,
+  or nil and false otherwise.
+
+  Running time: O(1) amortized
+*/
+func (h *FibHeap[K, V]) owns(handle Handle[K, V]) (*node[K, V], bool) {
+	return handle.owner(h.id)
 }
 
 /*
   Removes all elements from this heap.
+  Handles to the removed elements are no longer valid.
 
   Running time: O(1)
 */
-func (h *FibHeap) Clear() {
+func (h *FibHeap[K, V]) Clear() {
 	h.min = nil
-	h.free = nil
 	h.length = 0
+	h.id = &heapID{}
+	h.version++
 }
 
 /*
@@ -74,11 +126,11 @@ func (h *FibHeap) Clear() {
 
   Running time: O(log n) amortized
 */
-func (h *FibHeap) consolidate() {
+func (h *FibHeap[K, V]) consolidate() {
 	// The magic 45 comes from log base phi of Integer.MAX_VALUE,
 	// which is the most elements we will ever hold, and log base
 	// phi represents the largest degree of any root list node.
-	A := make([]*fibNode, 45)
+	A := make([]*node[K, V], 45)
 
 	// For each root list node look for others of the same degree.
 	start := h.min
@@ -91,7 +143,7 @@ func (h *FibHeap) consolidate() {
 		for A[d] != nil {
 			// Make one of the nodes a child of the other.
 			y := A[d]
-			if x.key > y.key {
+			if h.lessNode(y, x) {
 				x, y = y, x
 			}
 			if y == start {
@@ -127,97 +179,116 @@ func (h *FibHeap) consolidate() {
 
 	// Find the minimum key again.
 	for _, a := range A {
-		if a != nil && a.key < h.min.key {
+		if a != nil && h.lessNode(a, h.min) {
 			h.min = a
 		}
 	}
 }
 
 /*
-  Deletes a node from the heap given the data handle.
+  Deletes the element of the handle from the heap.
   The trees in the heap will be consolidated, if necessary.
 
   Running time: O(log n)
 
-  data:  data handle to remove from heap.
+  handle:  handle of the element to remove from heap.
+
+  Returns true if the element was removed, false if it is not in the heap.
 */
-func (h *FibHeap) Delete(data int) {
-	n, ok := find(h.min, data)
+func (h *FibHeap[K, V]) Delete(handle Handle[K, V]) bool {
+	n, ok := h.owns(handle)
 	if ok {
-		// make n the minimum node.
-		h.decreaseKey(n, -math.MaxFloat64, true)
-		// remove it
-		h.DeleteMin()
+		h.extract(n)
+		n.remove()
 	}
+	return ok
 }
 
 /*
-  Finds the first node with the given data handle
-*/
-func find(n *fibNode, data int) (*fibNode, bool) {
-	if n == nil {
-		return nil, false
-	}
-
-	if n.data == data {
-		return n, true
-	}
+  Removes the node from the heap, without detaching it,
+  so that it can be inserted again.
 
-	for sibling := n.right; sibling != n; sibling = sibling.right {
-		if sibling.data == data {
-			return sibling, true
-		}
-		if child, ok := find(sibling.child, data); ok {
-			return child, ok
-		}
-	}
-	return nil, false
+  Running time: O(log n) amortized
+*/
+func (h *FibHeap[K, V]) extract(n *node[K, V]) {
+	// make n the minimum node.
+	h.toTop(n)
+	// remove it
+	h.deleteMin()
 }
 
 /*
-  Decreases the key value for a heap node, given the new value
+  Decreases the key value for a heap element, given the new value
   to take on. The structure of the heap may be changed, but will
   not be consolidated.
 
-  Running time: O(log N) amortized
+  Running time: O(1) amortized
 
-  x:  node to decrease the key of.
+  handle:  handle of the element to decrease the key of.
 
-  k:  new key value for node x.
+  k:  new key value for the element.
 
+  Returns true if the key was decreased, false if the element is not
+  in the heap or k is ordered after the current key.
 */
-func (h *FibHeap) DecreaseKey(data int, k float64) bool {
-	x, ok := find(h.min, data)
-	if ok {
-		return h.decreaseKey(x, k, false)
+func (h *FibHeap[K, V]) DecreaseKey(handle Handle[K, V], k K) bool {
+	x, ok := h.owns(handle)
+	if !ok || h.less(x.key, k) {
+		return false
+	}
+	x.key = k
+	h.version++
+	y := x.parent
+	if y != nil && h.lessNode(x, y) {
+		y.cut(x, h.min)
+		y.cascadingCut(h.min)
+	}
+	if h.lessNode(x, h.min) {
+		h.min = x
 	}
-	return false
+	return true
 }
 
 /*
-  Decrease the key value of a node, or simply bubble it up to the
-  top of the heap in preparation for a delete operation.
+  Increases the key value for a heap element, given the new value
+  to take on. The element is removed and inserted again, the handle
+  stays valid.
 
-  Running time: O(1) amortized
+  Running time: O(log n) amortized
+
+  handle:  handle of the element to increase the key of.
 
-  x:       node to decrease the key of.
-  k:       new key value for node x.
-  del:     true if deleting node (in which case, k is ignored).
+  k:  new key value for the element.
+
+  Returns true if the key was increased, false if the element is not
+  in the heap or k is ordered before the current key.
 */
-func (h *FibHeap) decreaseKey(x *fibNode, k float64, del bool) bool {
-	if !del && k > x.key {
+func (h *FibHeap[K, V]) IncreaseKey(handle Handle[K, V], k K) bool {
+	x, ok := h.owns(handle)
+	if !ok || h.less(k, x.key) {
 		return false
 	}
+	h.extract(x)
 	x.key = k
+	h.insert(x)
+	return true
+}
+
+/*
+  Simply bubbles a node up to the top of the heap
+  in preparation for a delete operation.
+
+  Running time: O(1) amortized
+
+  x:       node to move to the top.
+*/
+func (h *FibHeap[K, V]) toTop(x *node[K, V]) {
 	y := x.parent
-	if y != nil && (del || k < y.key) {
+	if y != nil {
 		y.cut(x, h.min)
 		y.cascadingCut(h.min)
 	}
-	if del || k < h.min.key {
-		h.min = x
-	}
-	return true
+	h.min = x
 }
 
 /*
@@ -228,62 +299,117 @@ func (h *FibHeap) decreaseKey(x *fibNode, k float64, del bool) bool {
 
   Returns true if the heap is empty, false otherwise.
 */
-func (h *FibHeap) IsEmpty() bool {
+func (h *FibHeap[K, V]) IsEmpty() bool {
 	return h.min == nil
 }
 
 /*
-  Inserts a new data element into the heap. No heap consolidation
+  Inserts a new element into the heap. No heap consolidation
   is performed at this time, the new node is simply inserted into
   the root list of this heap.
 
   Running time: O(1)
 
-  data:    data handle to insert into heap.
+  value:  value to insert into heap, values don't have to be unique.
 
-  key:    key value associated with data handle.
+  key:    key value associated with the value.
 
-  Returns the newly created heap node.
+  Returns the handle of the new element.
 */
-func (h *FibHeap) Insert(data int, key float64) {
-	if data < 0 {
-		panic("Negative data handles are not supported")
-	}
+func (h *FibHeap[K, V]) Insert(value V, key K) Handle[K, V] {
+	n := newNode(h.id, value, key)
+	h.insert(n)
+	return Handle[K, V]{n}
+}
 
-	node := h.newNode(data, key)
+/*
+  Inserts the node into the root list of this heap.
+*/
+func (h *FibHeap[K, V]) insert(n *node[K, V]) {
+	n.reset()
+	n.left = n
+	n.right = n
 	// concatenate node into min list
 	if h.min != nil {
-		node.right = h.min
-		node.left = h.min.left
-		h.min.left = node
-		node.left.right = node
-		if key < h.min.key {
-			h.min = node
+		n.right = h.min
+		n.left = h.min.left
+		h.min.left = n
+		n.left.right = n
+		if h.lessNode(n, h.min) {
+			h.min = n
 		}
 	} else {
-		h.min = node
+		h.min = n
 	}
 	h.length++
+	h.version++
 }
 
-func (h *FibHeap) Contains(data int) bool {
-	_, ok := find(h.min, data)
+/*
+  Returns true if the element of the handle is in the heap.
+
+  Running time: O(1) amortized
+*/
+func (h *FibHeap[K, V]) Contains(handle Handle[K, V]) bool {
+	_, ok := h.owns(handle)
 	return ok
 }
 
+/*
+  Returns the value of the element of the handle and true,
+  or the zero value and false if the element is not in the heap.
+
+  Running time: O(1) amortized
+*/
+func (h *FibHeap[K, V]) Value(handle Handle[K, V]) (V, bool) {
+	if n, ok := h.owns(handle); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+/*
+  Returns the key of the element of the handle and true,
+  or the zero key and false if the element is not in the heap.
+
+  Running time: O(1) amortized
+*/
+func (h *FibHeap[K, V]) Key(handle Handle[K, V]) (K, bool) {
+	if n, ok := h.owns(handle); ok {
+		return n.key, true
+	}
+	var zero K
+	return zero, false
+}
+
 /*
   Returns the smallest element in the heap. This smallest element
   is the one with the minimum key value.
 
   Running time: O(1)
 
-  Returns the data handle with the smallest key and true, or 0 and false if empty.
+  Returns the value with the smallest key and true, or the zero value and false if empty.
+*/
+func (h *FibHeap[K, V]) Min() (V, bool) {
+	if h.min == nil {
+		var zero V
+		return zero, false
+	}
+	return h.min.value, true
+}
+
+/*
+  Returns the handle of the smallest element in the heap and true,
+  or the zero Handle and false if empty.
+
+  Running time: O(1)
 */
-func (h *FibHeap) Min() (int, bool) {
+func (h *FibHeap[K, V]) MinHandle() (Handle[K, V], bool) {
 	if h.min == nil {
-		return 0, false
+		return Handle[K, V]{}, false
 	}
-	return h.min.data, true
+	return Handle[K, V]{h.min}, true
 }
 
 /*
@@ -292,14 +418,23 @@ func (h *FibHeap) Min() (int, bool) {
 
   Running time: O(log n) amortized
 
-  Returns the  data handle with the smallest key and true, or 0 and false if the heap is empty.
+  Returns the value with the smallest key and true, or the zero value and false if the heap is empty.
 */
-func (h *FibHeap) DeleteMin() (int, bool) {
+func (h *FibHeap[K, V]) DeleteMin() (V, bool) {
 	z := h.min
 	if z == nil {
-		return 0, false
+		var zero V
+		return zero, false
 	}
-	data := z.data
+	h.deleteMin()
+	return z.remove(), true
+}
+
+/*
+  Removes the minimum node from the heap, without detaching it.
+*/
+func (h *FibHeap[K, V]) deleteMin() {
+	z := h.min
 	if z.child != nil {
 		z.child.parent = nil
 		// for each child of z do...
@@ -326,8 +461,7 @@ func (h *FibHeap) DeleteMin() (int, bool) {
 	}
 	// decrement size of heap
 	h.length--
-	h.addFree(z)
-	return data, true
+	h.version++
 }
 
 /*
@@ -338,109 +472,50 @@ func (h *FibHeap) DeleteMin() (int, bool) {
 
   Returns the number of elements in the heap.
 */
-func (h *FibHeap) Len() int {
+func (h *FibHeap[K, V]) Len() int {
 	return h.length
 }
 
 /*
   Moves the items of h2 to this one. No heap consolidation is
   performed at this time. The two root lists are simply joined together.
-  h2 wil be empty after the Join
+  h2 wil be empty after the Join, and the handles of the moved items
+  refer to items of this heap.
 
-  Running time: O(1)
+  If h2 is not a FibHeap, its items are moved one by one, and
+  the handles of the moved items are no longer valid.
+
+  Running time: O(1), or O(m log m) if h2 is not a FibHeap
 
   h2  the heap to join
 */
-func (h1 *FibHeap) Join(h2 *FibHeap) {
-	h := FibHeap{} // temp storage
-	if h1 != nil && h2 != nil {
-		h.min = h1.min
-		if h.min != nil {
-			if h2.min != nil {
-				h.min.right.left = h2.min.left
-				h2.min.left.right = h.min.right
-				h.min.right = h2.min
-				h2.min.left = h.min
-				if h2.min.key < h1.min.key {
-					h.min = h2.min
-				}
+func (h1 *FibHeap[K, V]) Join(other Heap[K, V]) {
+	h2, ok := other.(*FibHeap[K, V])
+	if !ok {
+		moveAll[K, V](h1, other)
+		return
+	}
+	if h1 == nil || h2 == nil || h1 == h2 {
+		return
+	}
+	if h2.min != nil {
+		if h1.min != nil {
+			h1.min.right.left = h2.min.left
+			h2.min.left.right = h1.min.right
+			h1.min.right = h2.min
+			h2.min.left = h1.min
+			if h1.lessNode(h2.min, h1.min) {
+				h1.min = h2.min
 			}
 		} else {
-			h.min = h2.min
+			h1.min = h2.min
 		}
-		h.length = h1.length + h2.length
+		h1.length += h2.length
+		h1.version++
+		// forward the nodes of h2 to h1
+		h2.id.joined = h1.id
 	}
 	h2.Clear()
-	h1.min = h.min
-	h1.length = h.length
-}
-
-/*
-  Implements a node of the Fibonacci heap. It holds the information
-  necessary for maintaining the structure of the heap. It acts as
-  an opaque handle for the data element, and serves as the key to
-  retrieving the data from the heap.
-*/
-type fibNode struct {
-	/* Key value for this node. */
-	key float64
-	/* Parent node. */
-	parent *fibNode
-	/* First child node. */
-	child *fibNode
-	/* Right sibling node. */
-	right *fibNode
-	/* Left sibling node. */
-	left *fibNode
-	/* handle to the data */
-	data int
-	/* Number of children of this node. */
-	degree int
-	/* True if this node has had a child removed since this node was
-	   added to its parent. */
-	mark bool
-}
-
-/*
-  Gets a free node or creates a new node for the fibonacci heap,
-
-  data:  data handle to associate with the new node
-
-  key:   key value for the data handle
-*/
-func (h *FibHeap) newNode(data int, key float64) *fibNode {
-	n := h.free
-	if n == nil {
-		n = &fibNode{}
-	} else {
-		h.free = n.child
-	}
-	n.reset(data, key)
-	return n
-}
-
-/*
-  Adds a node to the free list for the fibonacci heap,
-*/
-func (h *FibHeap) addFree(n *fibNode) {
-	if n == nil {
-		return
-	}
-	n.reset(0, 0)
-	n.child = h.free
-	h.free = n
-}
-
-// resets the FibNode to it's initial state
-func (n *fibNode) reset(data int, key float64) {
-	n.data = data
-	n.key = key
-	n.left = n
-	n.right = n
-	n.degree = 0
-	n.mark = false
-	n.parent = nil
-	n.child = nil
 }
 
 /*
@@ -451,7 +526,7 @@ func (n *fibNode) reset(data int, key float64) {
 
   min:  the minimum heap node, to which nodes will be added.
 */
-func (n *fibNode) cascadingCut(min *fibNode) {
+func (n *node[K, V]) cascadingCut(min *node[K, V]) {
 	p := n.parent
 	// if there's a parent...
 	if p != nil {
@@ -477,7 +552,7 @@ func (n *fibNode) cascadingCut(min *fibNode) {
 
   min:  the minimum heap node, to which x is added.
 */
-func (n *fibNode) cut(x, min *fibNode) {
+func (n *node[K, V]) cut(x, min *node[K, V]) {
 	// remove x from childlist and decrement degree
 	x.left.right = x.right
 	x.right.left = x.left
@@ -506,7 +581,7 @@ func (n *fibNode) cut(x, min *fibNode) {
 
   parent:  the new parent node.
 */
-func (n *fibNode) link(parent *fibNode) {
+func (n *node[K, V]) link(parent *node[K, V]) {
 	// Note: putting this code here in Node makes it faster
 	// because it doesn't have to use generated accessor methods,
 	// which add a lot of time when called millions of times.
@@ -0,0 +1,61 @@
+package heap
+
+/*
+Removes the elements of a FibHeap in key order, see FibHeap.Consumer.
+
+FibConsumer satisfies the c3.Consumer interface, Value returns
+the value of the removed element as an interface{}.
+*/
+type FibConsumer[K, V any] struct {
+	h     *FibHeap[K, V]
+	key   K
+	value V
+	ok    bool
+}
+
+/*
+Returns a consumer that removes the elements of the heap with DeleteMin.
+*/
+func (h *FibHeap[K, V]) Consumer() *FibConsumer[K, V] {
+	return &FibConsumer[K, V]{h: h}
+}
+
+/*
+Removes the element with the minimum key from the heap,
+returns true on success or false if the heap is empty.
+*/
+func (c *FibConsumer[K, V]) MoveNext() bool {
+	if c.h.min == nil {
+		var key K
+		var value V
+		c.key, c.value, c.ok = key, value, false
+		return false
+	}
+	c.key = c.h.min.key
+	c.value, c.ok = c.h.DeleteMin()
+	return true
+}
+
+/*
+Returns the value of the removed element, or nil.
+*/
+func (c *FibConsumer[K, V]) Value() interface{} {
+	if !c.ok {
+		return nil
+	}
+	return c.value
+}
+
+/*
+Returns the value of the removed element, or the zero value.
+*/
+func (c *FibConsumer[K, V]) Item() V {
+	return c.value
+}
+
+/*
+Returns the key of the removed element, or the zero key.
+*/
+func (c *FibConsumer[K, V]) Key() K {
+	return c.key
+}
@@ -0,0 +1,119 @@
+package heap
+
+/*
+Iterates over the elements of a FibHeap without removing them,
+in no particular order. It walks the root list and the child lists
+of the heap, and panics if the heap is modified while iterating.
+
+FibIterator satisfies the c3.Iterator interface, Value returns
+the value of the current element as an interface{}.
+*/
+type FibIterator[K, V any] struct {
+	h       *FibHeap[K, V]
+	current *node[K, V]
+	started bool
+	version int
+}
+
+/*
+Returns a new iterator over the elements of the heap.
+*/
+func (h *FibHeap[K, V]) Iterator() *FibIterator[K, V] {
+	return &FibIterator[K, V]{h: h, version: h.version}
+}
+
+/*
+Moves the iterator to the next element, returns true on success
+or false if there are no more elements.
+*/
+func (i *FibIterator[K, V]) MoveNext() bool {
+	if i.h == nil {
+		return false
+	}
+	if i.version != i.h.version {
+		i.current = nil
+		panic("Concurrent modification detected")
+	}
+	if !i.started {
+		i.started = true
+		i.current = i.h.min
+	} else if i.current != nil {
+		i.current = i.h.next(i.current)
+	}
+	if i.current == nil {
+		i.h = nil
+		return false
+	}
+	return true
+}
+
+/*
+Returns the value of the current element, or nil.
+*/
+func (i *FibIterator[K, V]) Value() interface{} {
+	if i.current == nil {
+		return nil
+	}
+	return i.current.value
+}
+
+/*
+Returns the value of the current element, or the zero value.
+*/
+func (i *FibIterator[K, V]) Item() V {
+	if i.current == nil {
+		var zero V
+		return zero
+	}
+	return i.current.value
+}
+
+/*
+Returns the key of the current element, or the zero key.
+*/
+func (i *FibIterator[K, V]) Key() K {
+	if i.current == nil {
+		var zero K
+		return zero
+	}
+	return i.current.key
+}
+
+/*
+Returns the handle of the current element, or the zero Handle.
+*/
+func (i *FibIterator[K, V]) Handle() Handle[K, V] {
+	return Handle[K, V]{i.current}
+}
+
+/*
+Ends the iteration.
+*/
+func (i *FibIterator[K, V]) Close() {
+	i.h = nil
+	i.current = nil
+}
+
+/*
+Returns the node after n in a depth first walk of the heap,
+or nil if n is the last node. The children of a node are
+visited before its right sibling.
+*/
+func (h *FibHeap[K, V]) next(n *node[K, V]) *node[K, V] {
+	if n.child != nil {
+		return n.child
+	}
+	for {
+		first := h.min
+		if n.parent != nil {
+			first = n.parent.child
+		}
+		if n.right != first {
+			return n.right
+		}
+		if n.parent == nil {
+			return nil
+		}
+		n = n.parent
+	}
+}
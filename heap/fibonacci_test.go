@@ -3,7 +3,7 @@ package heap
 import "testing"
 
 func TestFibInsert(t *testing.T) {
-	h := NewFibonacci()
+	h := NewFibonacci[int]()
 	h.Insert(1, 0.5)
 	h.Insert(2, 0.2)
 	h.Insert(3, 0.1)
@@ -11,3 +11,235 @@ func TestFibInsert(t *testing.T) {
 		t.Error("DeleteMin failed")
 	}
 }
+
+func TestFibDecreaseKeyOfChild(t *testing.T) {
+	h := NewFibonacci[int]()
+	handles := make([]Handle[float64, int], 10)
+	for n := 0; n < 10; n++ {
+		handles[n] = h.Insert(n, float64(n))
+	}
+	// consolidate the heap so that most nodes are children
+	h.DeleteMin()
+	if !h.Contains(handles[9]) {
+		t.Error("Contains(9) failed")
+	}
+	if !h.DecreaseKey(handles[9], -1) {
+		t.Error("DecreaseKey failed")
+	}
+	if x, ok := h.Min(); !ok || x != 9 {
+		t.Errorf("Expected 9, got %v", x)
+	}
+	if k, ok := h.Key(handles[9]); !ok || k != -1 {
+		t.Errorf("Expected key -1, got %v", k)
+	}
+}
+
+func TestFibFunc(t *testing.T) {
+	keys := []string{"c", "a", "d", "b"}
+	h := NewFibonacciFunc[string, int](Less[string])
+	for n, k := range keys {
+		h.Insert(n, k)
+	}
+	expected := []int{1, 3, 0, 2}
+	for _, e := range expected {
+		if x, ok := h.DeleteMin(); !ok || x != e {
+			t.Errorf("Expected %v, got %v", e, x)
+		}
+	}
+}
+
+func TestFibMax(t *testing.T) {
+	h := NewFibonacciMax[string]()
+	h.Insert("a", 1)
+	b := h.Insert("b", 2)
+	h.Insert("c", 3)
+	if x, _ := h.Min(); x != "c" {
+		t.Errorf("Expected c, got %v", x)
+	}
+	// decreasing a key in a max-heap moves it towards the top
+	if h.DecreaseKey(b, 1) {
+		t.Error("DecreaseKey to a lower priority succeeded")
+	}
+	if !h.DecreaseKey(b, 4) {
+		t.Error("DecreaseKey failed")
+	}
+	if x, _ := h.DeleteMin(); x != "b" {
+		t.Errorf("Expected b, got %v", x)
+	}
+}
+
+func TestFibIncreaseKey(t *testing.T) {
+	h := NewFibonacci[int]()
+	handles := make([]Handle[float64, int], 10)
+	for n := 0; n < 10; n++ {
+		handles[n] = h.Insert(n, float64(n))
+	}
+	// consolidate the heap so that most nodes have children
+	h.DeleteMin()
+	if h.IncreaseKey(handles[1], 0) {
+		t.Error("IncreaseKey to a lower key succeeded")
+	}
+	if !h.IncreaseKey(handles[1], 20) || !h.IncreaseKey(handles[4], 10) {
+		t.Error("IncreaseKey failed")
+	}
+	if h.Len() != 9 {
+		t.Errorf("Expected 9 items, got %v", h.Len())
+	}
+	expected := []int{2, 3, 5, 6, 7, 8, 9, 4, 1}
+	for _, e := range expected {
+		if x, ok := h.DeleteMin(); !ok || x != e {
+			t.Errorf("Expected %v, got %v", e, x)
+		}
+	}
+}
+
+type job struct {
+	priority int
+	time     int
+}
+
+func TestFibCompositeKeys(t *testing.T) {
+	// highest priority first, then earliest time
+	h := NewFibonacciFunc[job, string](func(a, b job) bool {
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		return a.time < b.time
+	})
+	h.Insert("late", job{1, 2})
+	h.Insert("early", job{1, 1})
+	h.Insert("urgent", job{2, 3})
+	expected := []string{"urgent", "early", "late"}
+	for _, e := range expected {
+		if x, ok := h.DeleteMin(); !ok || x != e {
+			t.Errorf("Expected %v, got %v", e, x)
+		}
+	}
+}
+
+func TestFibDuplicateValues(t *testing.T) {
+	h := NewFibonacci[string]()
+	a := h.Insert("x", 3)
+	b := h.Insert("x", 2)
+	h.Insert("y", 1)
+	if !h.Delete(b) {
+		t.Error("Delete failed")
+	}
+	if h.Contains(b) || !h.Contains(a) {
+		t.Error("Delete removed the wrong element")
+	}
+	if h.Delete(b) {
+		t.Error("Delete of a removed element succeeded")
+	}
+	if x, _ := h.DeleteMin(); x != "y" {
+		t.Errorf("Expected y, got %v", x)
+	}
+	if k, ok := h.Key(a); !ok || k != 3 {
+		t.Errorf("Expected key 3, got %v", k)
+	}
+}
+
+func TestFibHandlesAfterJoinAndClear(t *testing.T) {
+	h1 := NewFibonacci[int]()
+	h2 := NewFibonacci[int]()
+	a := h1.Insert(1, 1)
+	b := h2.Insert(2, 2)
+	if h1.Contains(b) {
+		t.Error("h1 contains an element of h2")
+	}
+	h1.Join(h2)
+	if !h1.Contains(b) || h2.Contains(b) || h2.Len() != 0 {
+		t.Error("Join did not move the elements of h2")
+	}
+	if !h1.DecreaseKey(b, 0) {
+		t.Error("DecreaseKey of a joined element failed")
+	}
+	if x, _ := h1.Min(); x != 2 {
+		t.Errorf("Expected 2, got %v", x)
+	}
+	// h2 is still usable after the join
+	c := h2.Insert(3, 3)
+	if !h2.Contains(c) || h1.Contains(c) {
+		t.Error("h2 is not usable after the join")
+	}
+	h1.Clear()
+	if h1.Contains(a) || h1.Contains(b) {
+		t.Error("Clear did not invalidate the handles")
+	}
+	if h1.Contains(Handle[float64, int]{}) {
+		t.Error("Contains of the zero Handle succeeded")
+	}
+}
+
+func TestFibIterator(t *testing.T) {
+	h := NewFibonacci[int]()
+	for n := 0; n < 20; n++ {
+		h.Insert(n, float64(n))
+	}
+	// consolidate the heap so that there are child lists to walk
+	h.DeleteMin()
+	seen := make(map[int]bool)
+	for i := h.Iterator(); i.MoveNext(); {
+		if k, _ := h.Key(i.Handle()); k != i.Key() || float64(i.Item()) != k {
+			t.Errorf("Key %v does not match item %v", i.Key(), i.Item())
+		}
+		seen[i.Value().(int)] = true
+	}
+	if len(seen) != 19 || seen[0] {
+		t.Errorf("Expected items 1 to 19, got %v", seen)
+	}
+	if h.Len() != 19 {
+		t.Error("Iterator modified the heap")
+	}
+	if i := NewFibonacci[int]().Iterator(); i.MoveNext() {
+		t.Error("MoveNext of an empty heap succeeded")
+	}
+}
+
+func TestFibIteratorConcurrentModification(t *testing.T) {
+	h := NewFibonacci[int]()
+	h.Insert(1, 1)
+	h.Insert(2, 2)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a concurrent modification panic")
+		}
+	}()
+	for i := h.Iterator(); i.MoveNext(); {
+		h.Insert(3, 3)
+	}
+}
+
+func TestFibIteratorJoin(t *testing.T) {
+	h1 := NewFibonacci[int]()
+	h1.Insert(1, 1)
+	h1.Insert(2, 2)
+	h2 := NewFibonacci[int]()
+	h2.Insert(3, 3)
+	i := h1.Iterator()
+	i.MoveNext()
+	h1.Join(h2)
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a concurrent modification panic after Join")
+		}
+	}()
+	i.MoveNext()
+}
+
+func TestFibConsumer(t *testing.T) {
+	h := NewFibonacciMax[string]()
+	h.Insert("b", 2)
+	h.Insert("c", 3)
+	h.Insert("a", 1)
+	expected := []string{"c", "b", "a"}
+	n := 0
+	for c := h.Consumer(); c.MoveNext(); n++ {
+		if c.Value() != expected[n] || c.Key() != float64(3-n) {
+			t.Errorf("Expected %v, got %v with key %v", expected[n], c.Value(), c.Key())
+		}
+	}
+	if n != 3 || !h.IsEmpty() {
+		t.Error("Consumer did not drain the heap")
+	}
+}
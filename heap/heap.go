@@ -0,0 +1,196 @@
+// Package heap provides priority heaps with handles to their elements.
+//
+// The heaps provided:
+//   - FibHeap: a Fibonacci heap, with O(1) amortized Insert, DecreaseKey and Join.
+//   - PairingHeap: a pairing heap, usually the fastest heap with DecreaseKey in practice.
+//   - DaryHeap: an array based d-ary heap, NewBinary creates a binary heap.
+//
+// All heaps implement the Heap interface. The keys of the elements are ordered
+// by a less function, the heaps are min-heaps with respect to that function.
+// Use the Less or Greater functions for min- or max-heaps of ordered keys.
+package heap
+
+import "cmp"
+
+// Heap is a priority heap of values ordered by key.
+type Heap[K, V any] interface {
+	// Inserts the value with the key into the heap,
+	// returns a handle to the new element.
+	Insert(value V, key K) Handle[K, V]
+	// Returns the value with the minimum key and true,
+	// or the zero value and false if the heap is empty.
+	Min() (V, bool)
+	// Returns the handle of the element with the minimum key and true,
+	// or the zero Handle and false if the heap is empty.
+	MinHandle() (Handle[K, V], bool)
+	// Removes the element with the minimum key from the heap,
+	// returns its value and true, or the zero value and false if the heap is empty.
+	DeleteMin() (V, bool)
+	// Changes the key of the element to a key that is not ordered after it,
+	// returns true on success, false if the element is not in the heap or k is ordered after its key.
+	DecreaseKey(handle Handle[K, V], k K) bool
+	// Changes the key of the element to a key that is not ordered before it,
+	// returns true on success, false if the element is not in the heap or k is ordered before its key.
+	IncreaseKey(handle Handle[K, V], k K) bool
+	// Removes the element from the heap,
+	// returns true on success, false if the element is not in the heap.
+	Delete(handle Handle[K, V]) bool
+	// Returns the key of the element and true,
+	// or the zero key and false if the element is not in the heap.
+	Key(handle Handle[K, V]) (K, bool)
+	// Returns the value of the element and true,
+	// or the zero value and false if the element is not in the heap.
+	Value(handle Handle[K, V]) (V, bool)
+	// Returns true if the element is in the heap.
+	Contains(handle Handle[K, V]) bool
+	// Moves all elements of the other heap into this heap, the other heap is empty afterwards.
+	Join(other Heap[K, V])
+	// Returns the number of elements in the heap.
+	Len() int
+	// Returns true if the heap is empty.
+	IsEmpty() bool
+	// Removes all elements from the heap.
+	Clear()
+}
+
+/*
+Refers to an element of a heap. A handle is returned by Insert,
+and stays valid until its element is removed from the heap.
+The zero Handle does not refer to any element.
+*/
+type Handle[K, V any] struct {
+	node *node[K, V]
+}
+
+/*
+Returns the node of the handle and true if the node belongs
+to the heap with the id, or nil and false otherwise.
+
+Running time: O(1) amortized
+*/
+func (handle Handle[K, V]) owner(heap *heapID) (*node[K, V], bool) {
+	n := handle.node
+	if n == nil || n.id == nil {
+		return nil, false
+	}
+	id := n.id
+	for id.joined != nil {
+		id = id.joined
+	}
+	// shorten the path for the next lookup
+	n.id = id
+	return n, id == heap
+}
+
+/*
+Identifies the heap that a node belongs to. When a heap is joined
+into another heap, its id is forwarded to the id of the other heap,
+so the moved nodes don't have to be visited.
+*/
+type heapID struct {
+	joined *heapID
+}
+
+/*
+Orders ordered keys ascending, for min-heaps.
+*/
+func Less[K cmp.Ordered](a, b K) bool {
+	return a < b
+}
+
+/*
+Orders ordered keys descending, for max-heaps.
+*/
+func Greater[K cmp.Ordered](a, b K) bool {
+	return a > b
+}
+
+/*
+Moves the elements of other into h one by one,
+used to join heaps of different types.
+
+Running time: O(m log m)
+*/
+func moveAll[K, V any](h, other Heap[K, V]) {
+	if other == nil || other == h {
+		return
+	}
+	for {
+		handle, ok := other.MinHandle()
+		if !ok {
+			return
+		}
+		key, _ := other.Key(handle)
+		value, _ := other.DeleteMin()
+		h.Insert(value, key)
+	}
+}
+
+/*
+Implements a node of a heap. It holds the information necessary
+for maintaining the structure of the heap, each heap uses the
+links it needs. It acts as an opaque handle for the value,
+and serves as the key to retrieving the value from the heap.
+*/
+type node[K, V any] struct {
+	/* Key value for this node. */
+	key K
+	/* Parent node. */
+	parent *node[K, V]
+	/* First child node. */
+	child *node[K, V]
+	/* Right sibling node. */
+	right *node[K, V]
+	/* Left sibling node, or the parent node of a first child in a pairing heap. */
+	left *node[K, V]
+	/* the value */
+	value V
+	/* the heap this node belongs to, nil if the node is removed */
+	id *heapID
+	/* Number of children of this node. */
+	degree int
+	/* Position of this node in the array of a d-ary heap. */
+	index int
+	/* True if this node has had a child removed since this node was
+	   added to its parent. */
+	mark bool
+}
+
+/*
+Creates a new node for a heap.
+Nodes are not reused, because handles may still refer to them.
+
+id:     the id of the heap of the node
+
+value:  value to associate with the new node
+
+key:    key value for the value
+*/
+func newNode[K, V any](id *heapID, value V, key K) *node[K, V] {
+	return &node[K, V]{key: key, value: value, id: id}
+}
+
+// resets the links of the node to their initial state
+func (n *node[K, V]) reset() {
+	n.left = nil
+	n.right = nil
+	n.degree = 0
+	n.index = 0
+	n.mark = false
+	n.parent = nil
+	n.child = nil
+}
+
+/*
+Detaches a node that was removed from the heap,
+so that handles to it no longer refer to the heap.
+Returns the value of the node.
+*/
+func (n *node[K, V]) remove() V {
+	value := n.value
+	var zero V
+	n.value = zero
+	n.id = nil
+	n.reset()
+	return value
+}
@@ -0,0 +1,213 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// the heap implementations, for the shared tests and benchmarks
+var heaps = []struct {
+	name   string
+	create func() Heap[float64, int]
+}{
+	{"Fibonacci", func() Heap[float64, int] { return NewFibonacci[int]() }},
+	{"Pairing", func() Heap[float64, int] { return NewPairing[int]() }},
+	{"Binary", func() Heap[float64, int] { return NewBinary[int]() }},
+	{"4-ary", func() Heap[float64, int] { return NewDary[int](4) }},
+}
+
+// drain removes all items from the heap and returns the keys in removal order
+func drain(t *testing.T, h Heap[float64, int]) []float64 {
+	keys := make([]float64, 0, h.Len())
+	for !h.IsEmpty() {
+		handle, _ := h.MinHandle()
+		key, _ := h.Key(handle)
+		value, _ := h.Min()
+		if x, ok := h.DeleteMin(); !ok || x != value {
+			t.Fatalf("DeleteMin returned %v, Min returned %v", x, value)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestHeapsRandomOperations(t *testing.T) {
+	for _, impl := range heaps {
+		t.Run(impl.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			h := impl.create()
+			handles := make([]Handle[float64, int], 0)
+			keys := make(map[int]float64)
+			for n := 0; n < 2000; n++ {
+				switch op := r.Intn(10); {
+				case op < 5 || len(handles) == 0:
+					key := float64(r.Intn(1000))
+					handles = append(handles, h.Insert(n, key))
+					keys[n] = key
+				case op < 7:
+					i := r.Intn(len(handles))
+					value, _ := h.Value(handles[i])
+					key := keys[value] - float64(r.Intn(100))
+					if !h.DecreaseKey(handles[i], key) {
+						t.Fatal("DecreaseKey failed")
+					}
+					keys[value] = key
+				case op < 8:
+					i := r.Intn(len(handles))
+					value, _ := h.Value(handles[i])
+					key := keys[value] + float64(r.Intn(100))
+					if !h.IncreaseKey(handles[i], key) {
+						t.Fatal("IncreaseKey failed")
+					}
+					keys[value] = key
+				case op < 9:
+					i := r.Intn(len(handles))
+					value, _ := h.Value(handles[i])
+					if !h.Delete(handles[i]) {
+						t.Fatal("Delete failed")
+					}
+					delete(keys, value)
+					handles = append(handles[:i], handles[i+1:]...)
+				default:
+					value, _ := h.DeleteMin()
+					for i, handle := range handles {
+						if !h.Contains(handle) {
+							handles = append(handles[:i], handles[i+1:]...)
+							break
+						}
+					}
+					delete(keys, value)
+				}
+				if h.Len() != len(keys) {
+					t.Fatalf("Expected %v items, got %v", len(keys), h.Len())
+				}
+			}
+			expected := make([]float64, 0, len(keys))
+			for _, key := range keys {
+				expected = append(expected, key)
+			}
+			sort.Float64s(expected)
+			actual := drain(t, h)
+			for i := range expected {
+				if expected[i] != actual[i] {
+					t.Fatalf("Expected key %v at %v, got %v", expected[i], i, actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHeapsJoin(t *testing.T) {
+	for _, impl := range heaps {
+		for _, other := range heaps {
+			t.Run(impl.name+"+"+other.name, func(t *testing.T) {
+				h1, h2 := impl.create(), other.create()
+				for n := 0; n < 20; n++ {
+					h1.Insert(n, float64(n*2))
+					h2.Insert(n, float64(n*2+1))
+				}
+				handle := h2.Insert(100, 100)
+				h1.Join(h2)
+				if h1.Len() != 41 || h2.Len() != 0 || h2.Contains(handle) {
+					t.Fatal("Join did not move the items")
+				}
+				if impl.name == other.name && !h1.DecreaseKey(handle, -1) {
+					t.Fatal("DecreaseKey of a joined item failed")
+				}
+				keys := drain(t, h1)
+				if !sort.Float64sAreSorted(keys) {
+					t.Fatalf("Keys not in order: %v", keys)
+				}
+			})
+		}
+	}
+}
+
+func TestHeapsMaxAndFunc(t *testing.T) {
+	maxHeaps := []Heap[float64, string]{
+		NewFibonacciMax[string](),
+		NewPairingMax[string](),
+		NewBinaryMax[string](),
+		NewDaryMax[string](3),
+	}
+	for _, h := range maxHeaps {
+		h.Insert("b", 2)
+		h.Insert("c", 3)
+		h.Insert("a", 1)
+		for _, e := range []string{"c", "b", "a"} {
+			if x, _ := h.DeleteMin(); x != e {
+				t.Errorf("%T: expected %v, got %v", h, e, x)
+			}
+		}
+	}
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	funcHeaps := []Heap[string, int]{
+		NewFibonacciFunc[string, int](byLength),
+		NewPairingFunc[string, int](byLength),
+		NewBinaryFunc[string, int](byLength),
+		NewDaryFunc[string, int](5, byLength),
+	}
+	for _, h := range funcHeaps {
+		h.Insert(3, "ccc")
+		h.Insert(1, "a")
+		h.Insert(2, "bb")
+		if x, _ := h.Min(); x != 1 {
+			t.Errorf("%T: expected 1, got %v", h, x)
+		}
+	}
+}
+
+func BenchmarkHeapsInsert1000(b *testing.B) {
+	for _, impl := range heaps {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := impl.create()
+				for n := 0; n < 1000; n++ {
+					h.Insert(n, float64((n*7919)%1000))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeapsInsertDeleteMin1000(b *testing.B) {
+	for _, impl := range heaps {
+		b.Run(impl.name, func(b *testing.B) {
+			h := impl.create()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for n := 0; n < 1000; n++ {
+					h.Insert(n, float64((n*7919)%1000))
+				}
+				for n := 0; n < 1000; n++ {
+					h.DeleteMin()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeapsDecreaseKey1000(b *testing.B) {
+	handles := make([]Handle[float64, int], 1000)
+	for _, impl := range heaps {
+		b.Run(impl.name, func(b *testing.B) {
+			h := impl.create()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for n := range handles {
+					handles[n] = h.Insert(n, float64(1000+n))
+				}
+				// a shortest path like mix of decreases and removals
+				for n := range handles {
+					h.DecreaseKey(handles[(n*7919)%1000], float64(n))
+					if n%4 == 3 {
+						h.DeleteMin()
+					}
+				}
+				h.Clear()
+			}
+		})
+	}
+}
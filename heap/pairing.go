@@ -0,0 +1,346 @@
+package heap
+
+/*
+PairingHeap implements a pairing heap.
+
+A pairing heap is a heap ordered multiway tree. Insert, Join and
+DecreaseKey meld trees in O(1), DeleteMin and Delete merge the
+children of the removed node in pairs in O(log n) amortized time.
+Pairing heaps are simple and usually faster in practice than
+Fibonacci heaps, even though the amortized bound of DecreaseKey is worse.
+
+The children of a node are linked through right, the left link of
+a node points to its left sibling, or to its parent if it is the first child.
+
+Note that this implementation is not synchronized.
+*/
+type PairingHeap[K, V any] struct {
+	/* the root of the tree, holds the minimum key */
+	root *node[K, V]
+	/* Number of nodes in the heap. */
+	length int
+	/* Orders the keys, the root has the key that is ordered first. */
+	less func(a, b K) bool
+	/* Identifies the nodes of this heap. */
+	id *heapID
+}
+
+/*
+Creates a pairing min-heap with float64 keys.
+*/
+func NewPairing[V any]() *PairingHeap[float64, V] {
+	return NewPairingFunc[float64, V](Less[float64])
+}
+
+/*
+Creates a pairing max-heap with float64 keys.
+*/
+func NewPairingMax[V any]() *PairingHeap[float64, V] {
+	return NewPairingFunc[float64, V](Greater[float64])
+}
+
+/*
+Creates a pairing heap that orders the keys with the less function.
+See NewFibonacciFunc.
+*/
+func NewPairingFunc[K, V any](less func(a, b K) bool) *PairingHeap[K, V] {
+	if less == nil {
+		panic("less function invalid")
+	}
+	return &PairingHeap[K, V]{nil, 0, less, &heapID{}}
+}
+
+/*
+Removes all elements from this heap.
+Handles to the removed elements are no longer valid.
+
+Running time: O(1)
+*/
+func (h *PairingHeap[K, V]) Clear() {
+	h.root = nil
+	h.length = 0
+	h.id = &heapID{}
+}
+
+/*
+Returns the number of elements in the heap.
+
+Running time: O(1)
+*/
+func (h *PairingHeap[K, V]) Len() int {
+	return h.length
+}
+
+/*
+Returns true if the heap is empty.
+
+Running time: O(1)
+*/
+func (h *PairingHeap[K, V]) IsEmpty() bool {
+	return h.root == nil
+}
+
+/*
+Inserts a new element into the heap.
+
+Running time: O(1)
+
+Returns the handle of the new element.
+*/
+func (h *PairingHeap[K, V]) Insert(value V, key K) Handle[K, V] {
+	n := newNode(h.id, value, key)
+	h.root = h.meld(h.root, n)
+	h.length++
+	return Handle[K, V]{n}
+}
+
+/*
+Returns the value with the minimum key and true,
+or the zero value and false if empty.
+
+Running time: O(1)
+*/
+func (h *PairingHeap[K, V]) Min() (V, bool) {
+	if h.root == nil {
+		var zero V
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+/*
+Returns the handle of the element with the minimum key and true,
+or the zero Handle and false if empty.
+
+Running time: O(1)
+*/
+func (h *PairingHeap[K, V]) MinHandle() (Handle[K, V], bool) {
+	if h.root == nil {
+		return Handle[K, V]{}, false
+	}
+	return Handle[K, V]{h.root}, true
+}
+
+/*
+Removes the element with the minimum key from the heap.
+
+Running time: O(log n) amortized
+
+Returns the value with the minimum key and true, or the zero value and false if empty.
+*/
+func (h *PairingHeap[K, V]) DeleteMin() (V, bool) {
+	n := h.root
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	h.extract(n)
+	return n.remove(), true
+}
+
+/*
+Deletes the element of the handle from the heap.
+
+Running time: O(log n) amortized
+
+Returns true if the element was removed, false if it is not in the heap.
+*/
+func (h *PairingHeap[K, V]) Delete(handle Handle[K, V]) bool {
+	n, ok := handle.owner(h.id)
+	if ok {
+		h.extract(n)
+		n.remove()
+	}
+	return ok
+}
+
+/*
+Decreases the key of the element of the handle, the tree of
+the element is cut from its parent and melded with the root.
+
+Running time: O(1), O(log n) amortized for the next DeleteMin
+
+Returns true if the key was decreased, false if the element is not
+in the heap or k is ordered after the current key.
+*/
+func (h *PairingHeap[K, V]) DecreaseKey(handle Handle[K, V], k K) bool {
+	n, ok := handle.owner(h.id)
+	if !ok || h.less(n.key, k) {
+		return false
+	}
+	n.key = k
+	if n != h.root {
+		h.detach(n)
+		h.root = h.meld(h.root, n)
+	}
+	return true
+}
+
+/*
+Increases the key of the element of the handle. The element is
+removed and inserted again, the handle stays valid.
+
+Running time: O(log n) amortized
+
+Returns true if the key was increased, false if the element is not
+in the heap or k is ordered before the current key.
+*/
+func (h *PairingHeap[K, V]) IncreaseKey(handle Handle[K, V], k K) bool {
+	n, ok := handle.owner(h.id)
+	if !ok || h.less(k, n.key) {
+		return false
+	}
+	h.extract(n)
+	n.key = k
+	h.root = h.meld(h.root, n)
+	h.length++
+	return true
+}
+
+/*
+Returns the key of the element of the handle and true,
+or the zero key and false if the element is not in the heap.
+*/
+func (h *PairingHeap[K, V]) Key(handle Handle[K, V]) (K, bool) {
+	if n, ok := handle.owner(h.id); ok {
+		return n.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+/*
+Returns the value of the element of the handle and true,
+or the zero value and false if the element is not in the heap.
+*/
+func (h *PairingHeap[K, V]) Value(handle Handle[K, V]) (V, bool) {
+	if n, ok := handle.owner(h.id); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+/*
+Returns true if the element of the handle is in the heap.
+*/
+func (h *PairingHeap[K, V]) Contains(handle Handle[K, V]) bool {
+	_, ok := handle.owner(h.id)
+	return ok
+}
+
+/*
+Moves the elements of the other heap to this heap,
+the other heap is empty after the Join.
+If the other heap is a PairingHeap the handles of the moved elements
+refer to elements of this heap, otherwise they are no longer valid.
+
+Running time: O(1), or O(m log m) if other is not a PairingHeap
+*/
+func (h *PairingHeap[K, V]) Join(other Heap[K, V]) {
+	o, ok := other.(*PairingHeap[K, V])
+	if !ok {
+		moveAll[K, V](h, other)
+		return
+	}
+	if o == nil || o == h {
+		return
+	}
+	if o.root != nil {
+		h.root = h.meld(h.root, o.root)
+		h.length += o.length
+		// forward the nodes of o to h
+		o.id.joined = h.id
+	}
+	o.Clear()
+}
+
+/*
+Removes the node from the heap, without detaching it,
+its children are merged and melded with the root.
+*/
+func (h *PairingHeap[K, V]) extract(n *node[K, V]) {
+	children := h.mergePairs(n.child)
+	if n == h.root {
+		h.root = children
+	} else {
+		h.detach(n)
+		h.root = h.meld(h.root, children)
+	}
+	n.reset()
+	h.length--
+}
+
+/*
+Cuts the tree of node n from its parent.
+*/
+func (h *PairingHeap[K, V]) detach(n *node[K, V]) {
+	if n.left.child == n {
+		// n is the first child
+		n.left.child = n.right
+	} else {
+		n.left.right = n.right
+	}
+	if n.right != nil {
+		n.right.left = n.left
+	}
+	n.left = nil
+	n.right = nil
+}
+
+/*
+Melds the trees of roots a and b, the root with the greater key
+becomes the first child of the other. Returns the new root.
+*/
+func (h *PairingHeap[K, V]) meld(a, b *node[K, V]) *node[K, V] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.key, a.key) {
+		a, b = b, a
+	}
+	b.left = a
+	b.right = a.child
+	if a.child != nil {
+		a.child.left = b
+	}
+	a.child = b
+	return a
+}
+
+/*
+Merges a list of sibling trees into one tree, with the
+two-pass method: meld the trees in pairs from left to right,
+then meld the pairs from right to left. Returns the new root.
+*/
+func (h *PairingHeap[K, V]) mergePairs(first *node[K, V]) *node[K, V] {
+	// first pass, the melded pairs are linked in reverse order
+	var pairs *node[K, V]
+	for a := first; a != nil; {
+		b := a.right
+		var next *node[K, V]
+		if b != nil {
+			next = b.right
+			b.left = nil
+			b.right = nil
+		}
+		a.left = nil
+		a.right = nil
+		pair := h.meld(a, b)
+		pair.right = pairs
+		pairs = pair
+		a = next
+	}
+	// second pass
+	var root *node[K, V]
+	for pairs != nil {
+		next := pairs.right
+		pairs.right = nil
+		root = h.meld(root, pairs)
+		pairs = next
+	}
+	return root
+}
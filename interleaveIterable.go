@@ -0,0 +1,14 @@
+package c3
+
+// interleaveIterable takes the items of several Iterables in turn, see Interleave.
+type interleaveIterable struct {
+	sources []Iterable
+}
+
+func (x *interleaveIterable) Iterator() Iterator {
+	iterators := make([]Iterator, len(x.sources))
+	for n, source := range x.sources {
+		iterators[n] = source.Iterator()
+	}
+	return &interleaveIterator{iterators, 0, nil, defaultElementValue}
+}
@@ -0,0 +1,47 @@
+package c3
+
+// interleaveIterator takes the next item of each iterator in turn,
+// and removes the iterators that have no more items.
+type interleaveIterator struct {
+	iterators []Iterator
+	next      int
+	err       error
+	value     interface{}
+}
+
+func (i *interleaveIterator) MoveNext() bool {
+	for len(i.iterators) > 0 {
+		i.next %= len(i.iterators)
+		it := i.iterators[i.next]
+		if it.MoveNext() {
+			i.value = it.Value()
+			i.next++
+			return true
+		}
+		if i.err = Err(it); i.err != nil {
+			// stop at the first error, like the other query operators
+			i.Close()
+			return false
+		}
+		Close(it)
+		i.iterators = append(i.iterators[:i.next], i.iterators[i.next+1:]...)
+	}
+	i.value = defaultElementValue
+	return false
+}
+
+func (i *interleaveIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *interleaveIterator) Close() {
+	for _, it := range i.iterators {
+		Close(it)
+	}
+	i.iterators = nil
+	i.value = defaultElementValue
+}
+
+func (i *interleaveIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,6 @@
+package c3
+
+// Iterator calls f to create a new Iterator.
+func (f IteratorFunc) Iterator() Iterator {
+	return f()
+}
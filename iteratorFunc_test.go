@@ -0,0 +1,23 @@
+package c3
+
+import (
+	"testing"
+
+	"github.com/ReSc/c3/heap"
+)
+
+func TestIteratorFuncQueriesFibHeap(t *testing.T) {
+	h := heap.NewFibonacci[int]()
+	for n := 0; n < 10; n++ {
+		h.Insert(n, float64(10-n))
+	}
+	q := NewQuery(IteratorFunc(func() Iterator { return h.Iterator() }))
+	assert(t, 5, q.Where(func(v interface{}) bool { return v.(int)%2 == 0 }).Count(), "q.Where().Count()")
+	assert(t, 10, h.Len(), "h.Len()")
+
+	var c Consumer = h.Consumer()
+	for n := 9; c.MoveNext(); n-- {
+		assert(t, n, c.Value(), "c.Value()")
+	}
+	assert(t, 0, h.Len(), "h.Len()")
+}
@@ -3,6 +3,8 @@ package c3
 type list struct {
 	version int
 	items   []interface{}
+	// eq compares the items, or nil to compare with ==
+	eq Equality
 }
 
 func (l *list) Iterator() Iterator {
@@ -80,7 +82,7 @@ func (l *list) IndexOf(item interface{}) (int, bool) {
 
 func (l *list) NextIndexOf(offset int, item interface{}) (int, bool) {
 	for index := max(-1, offset) + 1; 0 <= index && index < l.Len(); index++ {
-		if l.items[index] == item {
+		if l.equals(l.items[index], item) {
 			return index, true
 		}
 	}
@@ -93,7 +95,7 @@ func (l *list) LastIndexOf(item interface{}) (int, bool) {
 
 func (l *list) PrevIndexOf(offset int, item interface{}) (int, bool) {
 	for index := min(offset, l.Len()) - 1; 0 <= index && index < l.Len(); index-- {
-		if l.items[index] == item {
+		if l.equals(l.items[index], item) {
 			return index, true
 		}
 	}
@@ -124,3 +126,10 @@ func (l *list) DeleteAt(index int) bool {
 func (l *list) Len() int {
 	return len(l.items)
 }
+
+func (l *list) equals(a, b interface{}) bool {
+	if l.eq == nil {
+		return a == b
+	}
+	return l.eq.Equals(a, b)
+}
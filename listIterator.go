@@ -8,6 +8,10 @@ type listIterator struct {
 }
 
 func (i *listIterator) MoveNext() bool {
+	if i.l == nil {
+		return false
+	}
+
 	if i.version != i.l.version {
 		i.value = defaultElementValue
 		panic("Concurrent modification detected")
@@ -26,3 +30,8 @@ func (i *listIterator) MoveNext() bool {
 func (i *listIterator) Value() interface{} {
 	return i.value
 }
+
+func (i *listIterator) Close() {
+	i.l = nil
+	i.value = defaultElementValue
+}
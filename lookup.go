@@ -0,0 +1,100 @@
+package c3
+
+type lookup struct {
+	// the groups in the order in which their keys were added
+	groups *list
+	index  map[interface{}]*grouping
+}
+
+func (l *lookup) add(key, item interface{}) {
+	g, ok := l.index[key]
+	if !ok {
+		g = &grouping{key, newList()}
+		l.index[key] = g
+		l.groups.Add(g)
+	}
+	g.items.Add(item)
+}
+
+func (l *lookup) Iterator() Iterator {
+	return l.groups.Iterator()
+}
+
+func (l *lookup) Len() int {
+	return l.groups.Len()
+}
+
+func (l *lookup) Get(key interface{}) (Grouping, bool) {
+	g, ok := l.index[key]
+	if !ok {
+		return nil, false
+	}
+	return g, true
+}
+
+func (l *lookup) Items(key interface{}) Iterable {
+	if g, ok := l.index[key]; ok {
+		return g
+	}
+	return emptyIterable
+}
+
+func (l *lookup) ContainsKey(key interface{}) bool {
+	_, ok := l.index[key]
+	return ok
+}
+
+func (l *lookup) Keys() Iterable {
+	return NewQuery(l.groups).Select(func(g interface{}) interface{} {
+		return g.(*grouping).key
+	})
+}
+
+type grouping struct {
+	key   interface{}
+	items *list
+}
+
+func (g *grouping) Key() interface{} {
+	return g.key
+}
+
+func (g *grouping) Iterator() Iterator {
+	return g.items.Iterator()
+}
+
+func (g *grouping) Len() int {
+	return g.items.Len()
+}
+
+func (g *grouping) Contains(item interface{}) bool {
+	return g.items.Contains(item)
+}
+
+func (g *grouping) First() (interface{}, bool) {
+	return g.items.First()
+}
+
+func (g *grouping) Get(index int) (interface{}, bool) {
+	return g.items.Get(index)
+}
+
+func (g *grouping) Last() (interface{}, bool) {
+	return g.items.Last()
+}
+
+func (g *grouping) IndexOf(item interface{}) (int, bool) {
+	return g.items.IndexOf(item)
+}
+
+func (g *grouping) PrevIndexOf(offset int, item interface{}) (int, bool) {
+	return g.items.PrevIndexOf(offset, item)
+}
+
+func (g *grouping) NextIndexOf(offset int, item interface{}) (int, bool) {
+	return g.items.NextIndexOf(offset, item)
+}
+
+func (g *grouping) LastIndexOf(item interface{}) (int, bool) {
+	return g.items.LastIndexOf(item)
+}
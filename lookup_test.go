@@ -0,0 +1,56 @@
+package c3
+
+import "testing"
+
+func mod3(v interface{}) interface{} {
+	return v.(int) % 3
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := NewQuery(Range(1, 7)).GroupBy(mod3).ToSlice()
+	assert(t, 3, len(groups), "len(groups)")
+
+	expectedKeys := []int{1, 2, 0}
+	expectedItems := [][]int{{1, 4, 7}, {2, 5}, {3, 6}}
+	for index, x := range groups {
+		g := x.(Grouping)
+		assert(t, expectedKeys[index], g.Key(), "g.Key()")
+		assert(t, len(expectedItems[index]), g.Len(), "g.Len()")
+		for i, item := range expectedItems[index] {
+			value, _ := g.Get(i)
+			assert(t, item, value, "g.Get(i)")
+		}
+	}
+}
+
+func TestGroupByChained(t *testing.T) {
+	counts := NewQuery(Range(1, 7)).
+		GroupBy(mod3).
+		Where(func(g interface{}) bool { return g.(Grouping).Len() > 2 }).
+		Select(func(g interface{}) interface{} { return g.(Grouping).Key() }).
+		ToSlice()
+	assert(t, 1, len(counts), "len(counts)")
+	assert(t, 1, counts[0], "counts[0]")
+}
+
+func TestToLookup(t *testing.T) {
+	l := QueryOf("apple", "avocado", "banana", "cherry").ToLookup(func(v interface{}) interface{} {
+		return v.(string)[0]
+	})
+	assert(t, 3, l.Len(), "l.Len()")
+	assertb(t, true, l.ContainsKey(byte('a')), "l.ContainsKey('a')")
+	assert(t, 2, NewQuery(l.Items(byte('a'))).Count(), "count of 'a' items")
+	assert(t, 0, NewQuery(l.Items(byte('z'))).Count(), "count of 'z' items")
+
+	g, ok := l.Get(byte('b'))
+	assertb(t, true, ok, "ok")
+	first, _ := g.First()
+	assert(t, "banana", first, "first")
+
+	_, ok = l.Get(byte('z'))
+	assertb(t, false, ok, "ok")
+
+	keys := ToSlice(l.Keys())
+	assert(t, byte('a'), keys[0], "keys[0]")
+	assert(t, byte('c'), keys[2], "keys[2]")
+}
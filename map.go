@@ -0,0 +1,90 @@
+package c3
+
+type hashMap struct {
+	version int
+	items   map[interface{}]interface{}
+}
+
+func (m *hashMap) Len() int {
+	return len(m.items)
+}
+
+func (m *hashMap) Get(key interface{}) (interface{}, bool) {
+	value, ok := m.items[key]
+	return value, ok
+}
+
+func (m *hashMap) Put(key, value interface{}) bool {
+	_, replaced := m.items[key]
+	m.items[key] = value
+	m.version++
+	return !replaced
+}
+
+func (m *hashMap) Delete(key interface{}) bool {
+	if m.ContainsKey(key) {
+		delete(m.items, key)
+		m.version++
+		return true
+	}
+	return false
+}
+
+func (m *hashMap) ContainsKey(key interface{}) bool {
+	_, ok := m.items[key]
+	return ok
+}
+
+func (m *hashMap) Clear() {
+	if m.Len() == 0 {
+		return
+	}
+	m.items = make(map[interface{}]interface{})
+	m.version++
+}
+
+func (m *hashMap) Iterator() Iterator {
+	return m.Entries().Iterator()
+}
+
+func (m *hashMap) Keys() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return key
+	})
+}
+
+func (m *hashMap) Values() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return value
+	})
+}
+
+func (m *hashMap) Entries() Iterable {
+	return m.iterable(func(key, value interface{}) interface{} {
+		return KeyValue{key, value}
+	})
+}
+
+// iterable creates an Iterable that selects an item for every
+// entry in the map, and panics when the map is modified while iterating.
+func (m *hashMap) iterable(selector func(key, value interface{}) interface{}) Iterable {
+	return MakeIterable(func() Generate {
+		items := make([]interface{}, 0, m.Len())
+		for key, value := range m.items {
+			items = append(items, selector(key, value))
+		}
+		version := m.version
+		return func() (interface{}, bool) {
+			if m.version != version {
+				panic("Concurrent modification detected")
+			}
+			if len(items) == 0 {
+				return defaultElementValue, false
+			}
+
+			item := items[0]
+			items = items[1:]
+			return item, true
+		}
+	})
+}
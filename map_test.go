@@ -0,0 +1,67 @@
+package c3
+
+import "testing"
+
+func TestMap(t *testing.T) {
+	m := NewMap()
+	assert(t, 0, m.Len(), "m.Len()")
+
+	assertb(t, true, m.Put("a", 1), `m.Put("a", 1)`)
+	assertb(t, true, m.Put("b", 2), `m.Put("b", 2)`)
+	assertb(t, false, m.Put("a", 3), `m.Put("a", 3)`)
+	assert(t, 2, m.Len(), "m.Len()")
+
+	value, ok := m.Get("a")
+	assertb(t, true, ok, "ok")
+	assert(t, 3, value, "value")
+
+	value, ok = m.Get("c")
+	assertb(t, false, ok, "ok")
+	assert(t, nil, value, "value")
+
+	assertb(t, true, m.ContainsKey("b"), `m.ContainsKey("b")`)
+	assertb(t, true, m.Delete("b"), `m.Delete("b")`)
+	assertb(t, false, m.Delete("b"), `m.Delete("b")`)
+	assertb(t, false, m.ContainsKey("b"), `m.ContainsKey("b")`)
+
+	m.Clear()
+	assert(t, 0, m.Len(), "m.Len()")
+}
+
+func TestMapIterables(t *testing.T) {
+	m := NewQuery(Range(1, 3)).ToMap(
+		func(v interface{}) interface{} { return v.(int) * 10 },
+		func(v interface{}) interface{} { return v.(int) * 100 },
+	)
+	assert(t, 3, m.Len(), "m.Len()")
+
+	keys := NewQuery(m.Keys()).Aggregate(0, sum)
+	assert(t, 60, keys, "sum of keys")
+
+	values := NewQuery(m.Values()).Aggregate(0, sum)
+	assert(t, 600, values, "sum of values")
+
+	for i := m.Iterator(); i.MoveNext(); {
+		kv := i.Value().(KeyValue)
+		assert(t, kv.Key.(int)*10, kv.Value, "kv.Value")
+	}
+	assert(t, 3, NewQuery(m.Entries()).Count(), "entry count")
+}
+
+func TestMapConcurrentModification(t *testing.T) {
+	m := NewMap()
+	m.Put(1, 1)
+	m.Put(2, 2)
+	defer func() {
+		if recover() == nil {
+			fail(t, "expected a panic")
+		}
+	}()
+	for i := m.Keys().Iterator(); i.MoveNext(); {
+		m.Delete(i.Value())
+	}
+}
+
+func sum(item, aggregate interface{}) interface{} {
+	return item.(int) + aggregate.(int)
+}
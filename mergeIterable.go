@@ -0,0 +1,11 @@
+package c3
+
+// mergeIterable merges the items of several Iterables in the order
+// in which they are produced, see Merge.
+type mergeIterable struct {
+	sources []Iterable
+}
+
+func (m *mergeIterable) Iterator() Iterator {
+	return &mergeIterator{sources: m.sources}
+}
@@ -0,0 +1,82 @@
+package c3
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// mergeIterator iterates every source on its own goroutine,
+// and receives the items of all sources from a single channel.
+type mergeIterator struct {
+	sources []Iterable
+	items   chan interface{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	errs    []error
+	value   interface{}
+}
+
+func (i *mergeIterator) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+	i.items = make(chan interface{})
+	i.wg.Add(len(i.sources))
+	for _, source := range i.sources {
+		go func(source Iterable) {
+			defer i.wg.Done()
+			i.iterate(ctx, source)
+		}(source)
+	}
+	go func() {
+		i.wg.Wait()
+		close(i.items)
+	}()
+}
+
+func (i *mergeIterator) iterate(ctx context.Context, source Iterable) {
+	it := source.Iterator()
+	defer Close(it)
+	for ctx.Err() == nil && it.MoveNext() {
+		select {
+		case i.items <- it.Value():
+		case <-ctx.Done():
+			return
+		}
+	}
+	if err := Err(it); err != nil {
+		i.mu.Lock()
+		i.errs = append(i.errs, err)
+		i.mu.Unlock()
+	}
+}
+
+func (i *mergeIterator) MoveNext() bool {
+	if i.items == nil {
+		i.start()
+	}
+	value, ok := <-i.items
+	i.value = value
+	return ok
+}
+
+func (i *mergeIterator) Value() interface{} {
+	return i.value
+}
+
+// Close stops the goroutines of the sources, and waits until
+// they have closed the iterators of the sources.
+func (i *mergeIterator) Close() {
+	if i.cancel != nil {
+		i.cancel()
+		i.wg.Wait()
+	}
+	i.value = defaultElementValue
+}
+
+func (i *mergeIterator) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return errors.Join(i.errs...)
+}
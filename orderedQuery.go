@@ -0,0 +1,86 @@
+package c3
+
+import "sort"
+
+// OrderedQ is a query with sorted results,
+// that can be sorted further with ThenBy and ThenByDescending.
+type OrderedQ struct {
+	Q
+	source *Q
+	keys   []orderKey
+}
+
+type orderKey struct {
+	selector   Selector
+	compare    Comparer
+	descending bool
+}
+
+func newOrderedQ(source *Q, keys []orderKey, key orderKey) *OrderedQ {
+	q := &OrderedQ{source: source}
+	q.keys = append(append(make([]orderKey, 0, len(keys)+1), keys...), key)
+	q.result = IteratorFunc(q.sorted)
+	return q
+}
+
+// ThenBy sorts results with equal keys in ascending order of the
+// key computed by the keySelector, using compare to compare the keys.
+func (q *OrderedQ) ThenBy(keySelector Selector, compare Comparer) *OrderedQ {
+	return newOrderedQ(q.source, q.keys, orderKey{keySelector, compare, false})
+}
+
+// ThenByDescending sorts results with equal keys in descending order of the
+// key computed by the keySelector, using compare to compare the keys.
+func (q *OrderedQ) ThenByDescending(keySelector Selector, compare Comparer) *OrderedQ {
+	return newOrderedQ(q.source, q.keys, orderKey{keySelector, compare, true})
+}
+
+// sorted sorts the source results and returns an Iterator for the sorted results.
+func (q *OrderedQ) sorted() Iterator {
+	s := &orderSorter{keys: q.keys}
+	i := q.source.Iterator()
+	for i.MoveNext() {
+		item := i.Value()
+		keys := make([]interface{}, len(q.keys))
+		for k, key := range q.keys {
+			keys[k] = key.selector(item)
+		}
+		s.items = append(s.items, item)
+		s.itemKeys = append(s.itemKeys, keys)
+	}
+	if err := Err(i); err != nil {
+		return &errorIterator{emptyIterator, err}
+	}
+	sort.Stable(s)
+	return WrapList(s.items).Iterator()
+}
+
+// orderSorter sorts items on their precomputed keys.
+type orderSorter struct {
+	keys     []orderKey
+	items    []interface{}
+	itemKeys [][]interface{}
+}
+
+func (s *orderSorter) Len() int {
+	return len(s.items)
+}
+
+func (s *orderSorter) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.itemKeys[i], s.itemKeys[j] = s.itemKeys[j], s.itemKeys[i]
+}
+
+func (s *orderSorter) Less(i, j int) bool {
+	for k, key := range s.keys {
+		c := key.compare(s.itemKeys[i][k], s.itemKeys[j][k])
+		if c == 0 {
+			continue
+		}
+		if key.descending {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
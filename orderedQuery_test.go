@@ -0,0 +1,77 @@
+package c3
+
+import "testing"
+
+type person struct {
+	name string
+	age  int
+}
+
+var people = ListOf(
+	person{"dave", 30},
+	person{"alice", 25},
+	person{"carol", 30},
+	person{"bob", 25},
+	person{"erin", 35},
+)
+
+func age(p interface{}) interface{}  { return p.(person).age }
+func name(p interface{}) interface{} { return p.(person).name }
+
+func compareInts(a, b interface{}) int {
+	return a.(int) - b.(int)
+}
+
+func compareStrings(a, b interface{}) int {
+	x, y := a.(string), b.(string)
+	if x < y {
+		return -1
+	}
+	if x > y {
+		return 1
+	}
+	return 0
+}
+
+func names(people Iterable) string {
+	return NewQuery(people).Aggregate("", func(p, names interface{}) interface{} {
+		return names.(string) + p.(person).name[:1]
+	}).(string)
+}
+
+func TestOrderByIsStable(t *testing.T) {
+	assert(t, "abdce", names(NewQuery(people).OrderBy(age, compareInts)), "names")
+}
+
+func TestOrderByDescending(t *testing.T) {
+	assert(t, "edcab", names(NewQuery(people).OrderByDescending(age, compareInts)), "names")
+}
+
+func TestThenBy(t *testing.T) {
+	q := NewQuery(people).OrderBy(age, compareInts)
+	assert(t, "abcde", names(q.ThenBy(name, compareStrings)), "names")
+	assert(t, "badce", names(q.ThenByDescending(name, compareStrings)), "names")
+	assert(t, "ecdab", names(NewQuery(people).OrderByDescending(age, compareInts).ThenBy(name, compareStrings)), "names")
+	// the original ordering is not affected by ThenBy
+	assert(t, "abdce", names(q), "names")
+}
+
+func TestOrderByComposesWithQueries(t *testing.T) {
+	result := NewQuery(people).
+		OrderBy(name, compareStrings).
+		Where(func(p interface{}) bool { return p.(person).age < 35 }).
+		Take(2).
+		Select(name).
+		ToSlice()
+	assert(t, 2, len(result), "len(result)")
+	assert(t, "alice", result[0], "result[0]")
+	assert(t, "bob", result[1], "result[1]")
+}
+
+func TestSortStable(t *testing.T) {
+	l := ToList(people)
+	SortStable(l, func(a, b interface{}) bool {
+		return a.(person).age < b.(person).age
+	})
+	assert(t, "abdce", names(l), "names")
+}
@@ -0,0 +1,23 @@
+package c3
+
+import "fmt"
+
+// PanicError reports a panic in an action, see GoWait.
+type PanicError struct {
+	// the item that the action was applied to
+	Item interface{}
+	// the value passed to panic
+	Value interface{}
+	// the stack of the goroutine that panicked
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic for item %v: %v", e.Item, e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error, or nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -0,0 +1,201 @@
+package c3
+
+import "sync"
+
+// parallelBatch holds the results of the stages for the source item at index,
+// the value of a panic in the stages or in the source iterator,
+// or the error that stopped the source iterator.
+type parallelBatch struct {
+	index    int
+	results  []interface{}
+	panicked bool
+	panic    interface{}
+	err      error
+}
+
+// parallelIterator feeds the source items to the workers on a producer goroutine,
+// and merges the results of the workers. The window limits the number of
+// source items that are in progress, so the ordered mode does not buffer
+// an unbounded number of results while it waits for a slow item.
+type parallelIterator struct {
+	out     chan parallelBatch
+	done    chan struct{}
+	window  chan struct{}
+	ordered bool
+	next    int
+	pending map[int]parallelBatch
+	results []interface{}
+	value   interface{}
+	closed  bool
+	err     error
+	// the error that stopped the source iterator, and its index,
+	// written by the producer before it closes the input of the workers.
+	sourceErr   error
+	sourceIndex int
+	// the producer and the workers
+	wg sync.WaitGroup
+}
+
+func newParallelIterator(p *ParallelQ) *parallelIterator {
+	i := &parallelIterator{
+		out:     make(chan parallelBatch, p.workers),
+		done:    make(chan struct{}),
+		window:  make(chan struct{}, p.workers*4),
+		ordered: p.ordered,
+		pending: make(map[int]parallelBatch),
+	}
+	in := make(chan parallelBatch, p.workers)
+	i.wg.Add(1 + p.workers)
+	go func() {
+		defer i.wg.Done()
+		i.produce(p.source, in)
+	}()
+	for n := 0; n < p.workers; n++ {
+		go func() {
+			defer i.wg.Done()
+			i.work(p, in)
+		}()
+	}
+	go func() {
+		i.wg.Wait()
+		if i.sourceErr != nil {
+			// the workers are done, so the error follows all the results
+			select {
+			case i.out <- parallelBatch{index: i.sourceIndex, err: i.sourceErr}:
+			case <-i.done:
+			}
+		}
+		close(i.out)
+	}()
+	return i
+}
+
+// produce sends the source items to the workers.
+func (i *parallelIterator) produce(source Iterable, in chan<- parallelBatch) {
+	defer close(in)
+	defer func() {
+		if r := recover(); r != nil {
+			// the workers are still running, so out is not closed yet
+			select {
+			case i.out <- parallelBatch{index: -1, panicked: true, panic: r}:
+			case <-i.done:
+			}
+		}
+	}()
+	s := source.Iterator()
+	defer Close(s)
+	index := 0
+	for ; s.MoveNext(); index++ {
+		select {
+		case i.window <- struct{}{}:
+		case <-i.done:
+			return
+		}
+		select {
+		case in <- parallelBatch{index: index, results: []interface{}{s.Value()}}:
+		case <-i.done:
+			return
+		}
+	}
+	if err := Err(s); err != nil {
+		// the error follows the results of all the items, see newParallelIterator
+		select {
+		case i.window <- struct{}{}:
+		case <-i.done:
+			return
+		}
+		i.sourceErr, i.sourceIndex = err, index
+	}
+}
+
+// work runs the stages on the items, until the producer is done.
+func (i *parallelIterator) work(p *ParallelQ, in <-chan parallelBatch) {
+	for batch := range in {
+		batch = i.apply(p, batch)
+		select {
+		case i.out <- batch:
+		case <-i.done:
+			return
+		}
+	}
+}
+
+// apply runs the stages on the item of the batch, and recovers panics.
+func (i *parallelIterator) apply(p *ParallelQ, batch parallelBatch) (result parallelBatch) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = parallelBatch{index: batch.index, panicked: true, panic: r}
+		}
+	}()
+	batch.results = p.apply(batch.results[0])
+	return batch
+}
+
+// receive returns the next batch, in source order if the iterator is ordered,
+// re-panics on the consumer goroutine if a stage or the source panicked.
+func (i *parallelIterator) receive() (parallelBatch, bool) {
+	for {
+		if i.ordered {
+			if batch, ok := i.pending[i.next]; ok {
+				delete(i.pending, i.next)
+				i.next++
+				<-i.window
+				return batch, true
+			}
+		}
+		batch, ok := <-i.out
+		if !ok {
+			return batch, false
+		}
+		if batch.panicked {
+			i.Close()
+			panic(batch.panic)
+		}
+		if !i.ordered {
+			<-i.window
+			return batch, true
+		}
+		i.pending[batch.index] = batch
+	}
+}
+
+func (i *parallelIterator) MoveNext() bool {
+	for !i.closed {
+		if len(i.results) > 0 {
+			i.value = i.results[0]
+			i.results = i.results[1:]
+			return true
+		}
+		batch, ok := i.receive()
+		if !ok {
+			break
+		}
+		if batch.err != nil {
+			i.err = batch.err
+			break
+		}
+		i.results = batch.results
+	}
+	i.Close()
+	return false
+}
+
+func (i *parallelIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *parallelIterator) Err() error {
+	return i.err
+}
+
+// Close stops the producer and the workers, and waits until
+// they are done, so the source iterator is closed when Close returns.
+func (i *parallelIterator) Close() {
+	if !i.closed {
+		i.closed = true
+		close(i.done)
+		i.wg.Wait()
+	}
+	i.results = nil
+	i.value = defaultElementValue
+}
@@ -0,0 +1,119 @@
+package c3
+
+import "runtime"
+
+// ParallelQ is a query that runs its Where, Select and SelectMany stages
+// on a pool of worker goroutines. The results are merged back into a
+// normal Iterator, so all the methods of Q can be used on the results.
+//
+// By default the results are in no particular order, AsOrdered
+// keeps the results in the order of the source items.
+//
+// The stages run concurrently, so the functions passed to them
+// must be safe to call from multiple goroutines.
+// The iterator of a parallel query must be closed with Close
+// when the iteration is stopped before the end of the results.
+type ParallelQ struct {
+	Q
+	source  Iterable
+	workers int
+	ordered bool
+	stages  []parallelStage
+}
+
+// parallelStage computes zero or more results for an item,
+// and appends them to results.
+type parallelStage func(item interface{}, results []interface{}) []interface{}
+
+// Parallel runs the subsequent Where, Select and SelectMany stages of the query
+// on the given number of worker goroutines, or on runtime.GOMAXPROCS(0) workers
+// if workers is less than 1.
+func (q *Q) Parallel(workers int) *ParallelQ {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return newParallelQ(&ParallelQ{source: q.result, workers: workers})
+}
+
+func newParallelQ(p *ParallelQ) *ParallelQ {
+	p.result = &parallelIterable{p}
+	return p
+}
+
+// with returns a copy of the query with the stage added.
+func (p *ParallelQ) with(stage parallelStage) *ParallelQ {
+	stages := append(append(make([]parallelStage, 0, len(p.stages)+1), p.stages...), stage)
+	return newParallelQ(&ParallelQ{source: p.source, workers: p.workers, ordered: p.ordered, stages: stages})
+}
+
+// AsOrdered returns a parallel query that keeps the results
+// in the order of the source items.
+func (p *ParallelQ) AsOrdered() *ParallelQ {
+	return newParallelQ(&ParallelQ{source: p.source, workers: p.workers, ordered: true, stages: p.stages})
+}
+
+// AsUnordered returns a parallel query that returns the results
+// as soon as they are computed, in no particular order.
+func (p *ParallelQ) AsUnordered() *ParallelQ {
+	return newParallelQ(&ParallelQ{source: p.source, workers: p.workers, ordered: false, stages: p.stages})
+}
+
+// AsSequential returns a query that merges the results
+// of the parallel query, subsequent operators run sequentially.
+func (p *ParallelQ) AsSequential() *Q {
+	return &p.Q
+}
+
+// Filters the items on the worker goroutines using the filter function.
+// If filter returns true, the item is included
+// in the result, otherwise it is skipped.
+func (p *ParallelQ) Where(filter Predicate) *ParallelQ {
+	return p.with(func(item interface{}, results []interface{}) []interface{} {
+		if filter(item) {
+			results = append(results, item)
+		}
+		return results
+	})
+}
+
+// Select uses the selector on the worker goroutines
+// to create a new result for each item.
+func (p *ParallelQ) Select(selector Selector) *ParallelQ {
+	return p.with(func(item interface{}, results []interface{}) []interface{} {
+		return append(results, selector(item))
+	})
+}
+
+// SelectMany uses the selector on the worker goroutines to create an Iteratable
+// containing zero or more items for each item, and concatenates all the results.
+func (p *ParallelQ) SelectMany(selector ManySelector) *ParallelQ {
+	return p.with(func(item interface{}, results []interface{}) []interface{} {
+		i := selector(item).Iterator()
+		defer Close(i)
+		for i.MoveNext() {
+			results = append(results, i.Value())
+		}
+		return results
+	})
+}
+
+// apply runs the stages on the item.
+func (p *ParallelQ) apply(item interface{}) []interface{} {
+	items := []interface{}{item}
+	for _, stage := range p.stages {
+		results := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			results = stage(item, results)
+		}
+		items = results
+	}
+	return items
+}
+
+type parallelIterable struct {
+	p *ParallelQ
+}
+
+func (i *parallelIterable) Iterator() Iterator {
+	return newParallelIterator(i.p)
+}
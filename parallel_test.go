@@ -0,0 +1,106 @@
+package c3
+
+import (
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParallelOrdered(t *testing.T) {
+	result := NewQuery(Range(0, 99)).
+		Parallel(4).
+		AsOrdered().
+		Where(func(v interface{}) bool { return v.(int)%2 == 0 }).
+		Select(func(v interface{}) interface{} {
+			// make later items finish first
+			time.Sleep(time.Duration(100-v.(int)) * time.Microsecond)
+			return v.(int) * 10
+		}).
+		SelectMany(func(v interface{}) Iterable { return IterableOf(v, v.(int)+1) }).
+		ToList()
+
+	assert(t, 100, result.Len(), "result.Len()")
+	for n := 0; n < 50; n++ {
+		assertIndexOf(t, result, n*20, n*2)
+		assertIndexOf(t, result, n*20+1, n*2+1)
+	}
+}
+
+func TestParallelUnordered(t *testing.T) {
+	q := NewQuery(Range(0, 999)).
+		Parallel(0).
+		Select(func(v interface{}) interface{} { return v.(int) * 2 })
+
+	result := make([]int, 0, 1000)
+	for _, v := range q.ToSlice() {
+		result = append(result, v.(int))
+	}
+	sort.Ints(result)
+	assert(t, 1000, len(result), "len(result)")
+	for n, v := range result {
+		assert(t, n*2, v, "result[n]")
+	}
+
+	// the sequential operators run on the merged results
+	assert(t, 10, q.AsSequential().Where(func(v interface{}) bool { return v.(int) < 20 }).Count(), "Count()")
+}
+
+func TestParallelUnorderedSourceError(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		items, err := failAtFive().
+			Parallel(4).
+			AsUnordered().
+			Select(func(v interface{}) interface{} {
+				// make the items before the error finish last
+				time.Sleep(time.Duration(5-v.(int)) * time.Millisecond)
+				return v
+			}).
+			ToSliceE()
+
+		assert(t, errFive, err, "err")
+		assert(t, 4, len(items), "len(items)")
+	}
+}
+
+func TestParallelCloseStopsSource(t *testing.T) {
+	l := ToList(Range(1, 2000))
+	first, ok := NewQuery(l).Parallel(4).Select(func(v interface{}) interface{} { return v }).First()
+	assert(t, true, ok, "ok")
+	assertb(t, true, first.(int) >= 1, "first")
+	// the source is no longer iterated when First returns, run with -race
+	l.Add(1)
+}
+
+func TestParallelPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		assert(t, "boom", r, "recover()")
+	}()
+	NewQuery(Range(0, 99)).
+		Parallel(2).
+		Select(func(v interface{}) interface{} {
+			if v.(int) == 42 {
+				panic("boom")
+			}
+			return v
+		}).
+		Run()
+	fail(t, "expected a panic")
+}
+
+func TestParallelClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for n := 0; n < 10; n++ {
+		first, ok := NewQuery(Range(0, 1000000)).Parallel(4).AsOrdered().First()
+		assertb(t, true, ok, "ok")
+		assert(t, 0, first, "First()")
+	}
+	// the workers of the closed iterators stop
+	for n := 0; n < 100 && runtime.NumGoroutine() > before; n++ {
+		time.Sleep(time.Millisecond)
+	}
+	if runtime.NumGoroutine() > before {
+		failf(t, "%v goroutines leaked", runtime.NumGoroutine()-before)
+	}
+}
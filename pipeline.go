@@ -0,0 +1,71 @@
+package c3
+
+// Broadcast splits the query result into n streams that each yield all the results.
+// The source is iterated only once, and every stream buffers at most bufferSize results
+// that the other streams have already taken. A stream that is ahead of another stream
+// by more than bufferSize results waits for the other stream, so streams that
+// diverge must be iterated on separate goroutines. Every stream can be iterated once,
+// closing the iterator of a stream stops it from holding back the other streams.
+func (q *Q) Broadcast(n, bufferSize int) []*Q {
+	return newFanOut(q.result, n, bufferSize, func(item interface{}) int {
+		return broadcastToAll
+	})
+}
+
+// Partition splits the query result into a stream of the results for
+// which the predicate holds, and a stream of the other results.
+// The streams buffer results like the streams of Broadcast.
+func (q *Q) Partition(predicate Predicate, bufferSize int) (matching, rest *Q) {
+	streams := newFanOut(q.result, 2, bufferSize, func(item interface{}) int {
+		if predicate(item) {
+			return 0
+		}
+		return 1
+	})
+	return streams[0], streams[1]
+}
+
+// PartitionBy splits the query result into a stream per key, that yields the results
+// with that key, and a last stream that yields the results with any other key.
+// The streams buffer results like the streams of Broadcast.
+func (q *Q) PartitionBy(keySelector Selector, bufferSize int, keys ...interface{}) []*Q {
+	index := make(map[interface{}]int, len(keys))
+	for n := len(keys) - 1; n >= 0; n-- {
+		index[keys[n]] = n
+	}
+	return newFanOut(q.result, len(keys)+1, bufferSize, func(item interface{}) int {
+		if n, ok := index[keySelector(item)]; ok {
+			return n
+		}
+		return len(keys)
+	})
+}
+
+// Merge merges the query result with the other Iterables, see Merge.
+func (q *Q) Merge(others ...Iterable) *Q {
+	return Merge(append([]Iterable{q.result}, others...)...)
+}
+
+// Interleave interleaves the query result with the other Iterables, see Interleave.
+func (q *Q) Interleave(others ...Iterable) *Q {
+	return Interleave(append([]Iterable{q.result}, others...)...)
+}
+
+// Merge creates a query that iterates all the Iterables concurrently,
+// each on its own goroutine, and yields their items in the order in which they arrive.
+// Consumers can be merged by wrapping them in an IteratorFunc.
+// The iterator of a merged query must be closed with Close
+// when the iteration is stopped before the end of the results.
+func Merge(sources ...Iterable) *Q {
+	return NewQuery(&mergeIterable{sources})
+}
+
+// Interleave creates a query that takes an item of each Iterable in turn,
+// until all Iterables are exhausted.
+//
+// e.g.:
+//
+//	Interleave(ListOf(1, 2, 3), ListOf(4)) // yields [1, 4, 2, 3]
+func Interleave(sources ...Iterable) *Q {
+	return NewQuery(&interleaveIterable{sources})
+}
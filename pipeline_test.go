@@ -0,0 +1,147 @@
+package c3
+
+import (
+	"sync"
+	"testing"
+)
+
+func isEven(item interface{}) bool {
+	return item.(int)%2 == 0
+}
+
+func TestBroadcast(t *testing.T) {
+	streams := NewQuery(Range(1, 100)).Broadcast(3, 4)
+	counts := make([]int, len(streams))
+	var wg sync.WaitGroup
+	for n, stream := range streams {
+		wg.Add(1)
+		go func(n int, stream *Q) {
+			defer wg.Done()
+			counts[n] = stream.Count()
+		}(n, stream)
+	}
+	wg.Wait()
+	for n, count := range counts {
+		assert(t, 100, count, "count of stream "+string(rune('0'+n)))
+	}
+}
+
+func TestBroadcastWithinBuffer(t *testing.T) {
+	streams := NewQuery(Range(1, 3)).Broadcast(2, 3)
+	assertIndexOf(t, streams[0].ToList(), 3, 2)
+	assertIndexOf(t, streams[1].ToList(), 3, 2)
+}
+
+func TestBroadcastClosedStreamDoesNotHoldBack(t *testing.T) {
+	streams := NewQuery(Range(1, 100)).Broadcast(2, 1)
+	Close(streams[1].Iterator())
+	assert(t, 100, streams[0].Count(), "count")
+}
+
+func TestBroadcastIterateTwicePanics(t *testing.T) {
+	streams := NewQuery(Range(1, 3)).Broadcast(1, 1)
+	streams[0].Count()
+	defer func() {
+		if recover() == nil {
+			fail(t, "expected a panic")
+		}
+	}()
+	streams[0].Count()
+}
+
+func TestBroadcastComposes(t *testing.T) {
+	streams := NewQuery(Range(1, 10)).Broadcast(2, 10)
+	evens := streams[0].Where(isEven).Count()
+	sum := streams[1].Aggregate(0, func(item, sum interface{}) interface{} { return sum.(int) + item.(int) })
+	assert(t, 5, evens, "evens")
+	assert(t, 55, sum, "sum")
+}
+
+func TestBroadcastError(t *testing.T) {
+	streams := failAtFive().Broadcast(2, 10)
+	items, err := streams[0].ToSliceE()
+	assert(t, 4, len(items), "len(items)")
+	assert(t, errFive, err, "err of stream 0")
+	_, err = streams[1].ToSliceE()
+	assert(t, errFive, err, "err of stream 1")
+}
+
+func TestPartition(t *testing.T) {
+	evens, odds := NewQuery(Range(1, 100)).Partition(isEven, 2)
+	var evenCount, oddCount int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		evenCount = evens.Where(isEven).Count()
+	}()
+	go func() {
+		defer wg.Done()
+		oddCount = odds.Where(isEven).Count()
+	}()
+	wg.Wait()
+	assert(t, 50, evenCount, "evenCount")
+	assert(t, 0, oddCount, "oddCount")
+}
+
+func TestPartitionBy(t *testing.T) {
+	streams := NewQuery(Range(1, 10)).PartitionBy(func(item interface{}) interface{} {
+		return item.(int) % 3
+	}, 10, 0, 1)
+	assert(t, 3, len(streams), "len(streams)")
+	zeros := streams[0].ToList()
+	ones := streams[1].ToList()
+	rest := streams[2].ToList()
+	assert(t, 3, zeros.Len(), "zeros.Len()")
+	assert(t, 4, ones.Len(), "ones.Len()")
+	assert(t, 3, rest.Len(), "rest.Len()")
+	assertIndexOf(t, rest, 8, 2)
+}
+
+func TestMerge(t *testing.T) {
+	merged := NewQuery(Range(1, 50)).Merge(Range(51, 100), Range(101, 150))
+	assert(t, 150, merged.Count(), "count")
+	assert(t, 150, merged.Distinct().Count(), "distinct count")
+}
+
+func TestMergeConsumers(t *testing.T) {
+	q := NewBlockingQueue(0)
+	for n := 0; n < 10; n++ {
+		q.Put(n)
+	}
+	q.Close()
+	c := q.Consumer()
+	merged := Merge(IteratorFunc(func() Iterator { return c }), Range(1, 10))
+	assert(t, 20, merged.Count(), "count")
+}
+
+func TestMergeClose(t *testing.T) {
+	sources := []*iteratorRecorder{
+		{source: ToList(Repeat(1000, 1))},
+		{source: ToList(Repeat(1000, 2))},
+	}
+	i := Merge(sources[0], sources[1]).Iterator()
+	i.MoveNext()
+	Close(i)
+	for _, r := range sources {
+		assert(t, 1, len(r.iterators), "len(r.iterators)")
+		assertb(t, true, released(r.iterators[0]), "source iterator closed")
+	}
+}
+
+func TestMergeError(t *testing.T) {
+	_, err := Merge(Range(1, 10), failAtFive()).ToSliceE()
+	assert(t, true, err != nil, "err != nil")
+}
+
+func TestInterleave(t *testing.T) {
+	l := Interleave(ListOf(1, 2, 3), ListOf(4), ListOf(5, 6)).ToList()
+	assert(t, 6, l.Len(), "l.Len()")
+	for index, item := range []int{1, 4, 5, 2, 6, 3} {
+		assertIndexOf(t, l, item, index)
+	}
+
+	items, err := NewQuery(Range(1, 10)).Interleave(failAtFive()).ToSliceE()
+	assert(t, errFive, err, "err")
+	assert(t, 9, len(items), "len(items)")
+}
@@ -0,0 +1,204 @@
+package c3
+
+import (
+	"sort"
+
+	"github.com/ReSc/c3/heap"
+)
+
+// priorityQueue stores the items by handle, and the heap orders
+// the items by priority, and by handle for equal priorities,
+// so items with equal priorities are dequeued in the order in which they were added.
+type priorityQueue struct {
+	version int
+	heap    *heap.FibHeap[priorityKey, *priorityItem]
+	items   map[PriorityHandle]*priorityItem
+	next    PriorityHandle
+	less    Lesser
+	// computes the numeric priority of an item, nil if the priorities are not numeric
+	priority func(item interface{}) float64
+}
+
+type priorityItem struct {
+	handle   PriorityHandle
+	item     interface{}
+	priority interface{}
+	node     heap.Handle[priorityKey, *priorityItem]
+}
+
+// priorityKey is the key of an item in the heap.
+type priorityKey struct {
+	priority interface{}
+	handle   PriorityHandle
+}
+
+func newPriorityQueue(less Lesser) *priorityQueue {
+	q := &priorityQueue{
+		items: make(map[PriorityHandle]*priorityItem),
+		less:  less,
+	}
+	q.heap = heap.NewFibonacciFunc[priorityKey, *priorityItem](q.lessKey)
+	return q
+}
+
+// lessKey orders the keys by priority, and by handle for equal priorities.
+func (q *priorityQueue) lessKey(a, b priorityKey) bool {
+	if q.less(a.priority, b.priority) {
+		return true
+	}
+	return !q.less(b.priority, a.priority) && a.handle < b.handle
+}
+
+func (q *priorityQueue) Len() int {
+	return len(q.items)
+}
+
+func (q *priorityQueue) Clear() {
+	if len(q.items) == 0 {
+		return
+	}
+	q.heap.Clear()
+	q.items = make(map[PriorityHandle]*priorityItem)
+	q.version++
+}
+
+func (q *priorityQueue) Contains(item interface{}) bool {
+	for _, x := range q.items {
+		if x.item == item {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *priorityQueue) Peek() (interface{}, bool) {
+	if x, ok := q.heap.Min(); ok {
+		return x.item, true
+	}
+	return defaultElementValue, false
+}
+
+func (q *priorityQueue) Enqueue(item interface{}) bool {
+	if q.priority != nil {
+		q.EnqueueWithPriority(item, q.priority(item))
+	} else {
+		q.EnqueueWithPriority(item, item)
+	}
+	return true
+}
+
+func (q *priorityQueue) EnqueueWithPriority(item, priority interface{}) PriorityHandle {
+	handle := q.next
+	q.next++
+	x := &priorityItem{handle: handle, item: item, priority: priority}
+	x.node = q.heap.Insert(x, priorityKey{priority, handle})
+	q.items[handle] = x
+	q.version++
+	return handle
+}
+
+func (q *priorityQueue) Dequeue() (interface{}, bool) {
+	x, ok := q.heap.DeleteMin()
+	if !ok {
+		return defaultElementValue, false
+	}
+	delete(q.items, x.handle)
+	q.version++
+	return x.item, true
+}
+
+func (q *priorityQueue) Priority(handle PriorityHandle) (interface{}, bool) {
+	if x, ok := q.items[handle]; ok {
+		return x.priority, true
+	}
+	return defaultElementValue, false
+}
+
+func (q *priorityQueue) UpdatePriority(handle PriorityHandle, priority interface{}) bool {
+	x, ok := q.items[handle]
+	if !ok {
+		return false
+	}
+	key := priorityKey{priority, handle}
+	if q.lessKey(priorityKey{x.priority, handle}, key) {
+		q.heap.IncreaseKey(x.node, key)
+	} else {
+		q.heap.DecreaseKey(x.node, key)
+	}
+	x.priority = priority
+	q.version++
+	return true
+}
+
+func (q *priorityQueue) Remove(handle PriorityHandle) bool {
+	x, ok := q.items[handle]
+	if !ok {
+		return false
+	}
+	q.heap.Delete(x.node)
+	delete(q.items, handle)
+	q.version++
+	return true
+}
+
+func (q *priorityQueue) Iterator() Iterator {
+	return MakeIterable(func() Generate {
+		// sort a snapshot of the items, in the order
+		// in which they were added for equal priorities.
+		items := make([]*priorityItem, 0, len(q.items))
+		for _, x := range q.items {
+			items = append(items, x)
+		}
+		sort.Slice(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			return q.lessKey(priorityKey{a.priority, a.handle}, priorityKey{b.priority, b.handle})
+		})
+		version := q.version
+		return func() (interface{}, bool) {
+			if q.version != version {
+				panic("Concurrent modification detected")
+			}
+			if len(items) == 0 {
+				return defaultElementValue, false
+			}
+			item := items[0].item
+			items = items[1:]
+			return item, true
+		}
+	}).Iterator()
+}
+
+func (q *priorityQueue) Consumer() Consumer {
+	return &priorityQueueConsumer{q, defaultElementValue}
+}
+
+// toFloat converts an int or float value into a float64
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	panic("Priority is not a number")
+}
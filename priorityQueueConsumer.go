@@ -0,0 +1,16 @@
+package c3
+
+type priorityQueueConsumer struct {
+	q    *priorityQueue
+	item interface{}
+}
+
+func (c *priorityQueueConsumer) MoveNext() bool {
+	item, ok := c.q.Dequeue()
+	c.item = item
+	return ok
+}
+
+func (c *priorityQueueConsumer) Value() interface{} {
+	return c.item
+}
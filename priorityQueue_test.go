@@ -0,0 +1,102 @@
+package c3
+
+import "testing"
+
+func TestPriorityQueueLesser(t *testing.T) {
+	q := NewPriorityQueue(intLess)
+	for _, n := range []int{5, 3, 8, 1, 9, 2} {
+		q.Enqueue(n)
+	}
+	assert(t, 6, q.Len(), "q.Len()")
+	assertb(t, true, q.Contains(8), "q.Contains(8)")
+
+	item, _ := q.Peek()
+	assert(t, 1, item, "q.Peek()")
+
+	// iterating does not consume the queue
+	assertIndexOf(t, NewQuery(q).ToList(), 1, 0)
+	assertIndexOf(t, NewQuery(q).ToList(), 9, 5)
+	assert(t, 6, q.Len(), "q.Len()")
+
+	for _, n := range []int{1, 2, 3, 5, 8, 9} {
+		item, ok := q.Dequeue()
+		assertb(t, true, ok, "ok")
+		assert(t, n, item, "q.Dequeue()")
+	}
+	_, ok := q.Dequeue()
+	assertb(t, false, ok, "ok")
+}
+
+func TestPriorityQueueUpdatePriority(t *testing.T) {
+	q := NewPriorityQueue(intLess)
+	a := q.EnqueueWithPriority("a", 10)
+	b := q.EnqueueWithPriority("b", 20)
+	c := q.EnqueueWithPriority("c", 30)
+
+	assertb(t, true, q.UpdatePriority(c, 5), "q.UpdatePriority(c, 5)")
+	item, _ := q.Peek()
+	assert(t, "c", item, "q.Peek()")
+
+	assertb(t, true, q.UpdatePriority(c, 40), "q.UpdatePriority(c, 40)")
+	priority, _ := q.Priority(c)
+	assert(t, 40, priority, "q.Priority(c)")
+
+	assertb(t, true, q.Remove(a), "q.Remove(a)")
+	assertb(t, false, q.Remove(a), "q.Remove(a)")
+	assertb(t, false, q.UpdatePriority(a, 1), "q.UpdatePriority(a, 1)")
+
+	list := NewQuery(q).ToList()
+	assertIndexOf(t, list, "b", 0)
+	assertIndexOf(t, list, "c", 1)
+
+	assertb(t, true, q.UpdatePriority(b, 50), "q.UpdatePriority(b, 50)")
+	i := 0
+	for c := q.Consumer(); c.MoveNext(); i++ {
+		assert(t, []string{"c", "b"}[i], c.Value(), "c.Value()")
+	}
+	assert(t, 0, q.Len(), "q.Len()")
+}
+
+func TestPriorityQueueNumeric(t *testing.T) {
+	q := NewNumericPriorityQueue(func(item interface{}) float64 {
+		return float64(len(item.(string)))
+	})
+	q.Enqueue("ccc")
+	q.Enqueue("a")
+	h := q.EnqueueWithPriority("dddd", 2.5)
+	q.Enqueue("bb")
+	q.UpdatePriority(h, 0)
+
+	for _, s := range []string{"dddd", "a", "bb", "ccc"} {
+		item, _ := q.Dequeue()
+		assert(t, s, item, "q.Dequeue()")
+	}
+}
+
+func TestPriorityQueueEqualPriorities(t *testing.T) {
+	q := NewNumericPriorityQueue(func(item interface{}) float64 { return 1 })
+	for n := 0; n < 20; n++ {
+		q.EnqueueWithPriority(n, n%2)
+	}
+	iterated := ToSlice(q)
+	for n := 0; n < 20; n++ {
+		item, _ := q.Dequeue()
+		assert(t, iterated[n], item, "dequeued item")
+		// the even items first, in the order in which they were added
+		assert(t, n%10*2+n/10, item, "item")
+	}
+}
+
+func TestPriorityQueueConcurrentModification(t *testing.T) {
+	q := NewPriorityQueue(intLess)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	defer func() {
+		if recover() == nil {
+			fail(t, "expected a concurrent modification panic")
+		}
+	}()
+	for i := q.Iterator(); i.MoveNext(); {
+		q.Enqueue(3)
+	}
+}
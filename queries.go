@@ -1,5 +1,6 @@
 package c3
 
+import "context"
 import "math/rand"
 import "time"
 
@@ -11,6 +12,17 @@ type Q struct {
 // Action is invoked for every item in the query result.
 type Action func(item interface{})
 
+// ErrorAction is invoked for every item, and returns an error if the item could not be processed.
+type ErrorAction func(item interface{}) error
+
+// PredicateE is a Predicate that can fail,
+// it returns an error if the predicate could not be evaluated.
+type PredicateE func(item interface{}) (bool, error)
+
+// SelectorE is a Selector that can fail,
+// it returns an error if the item could not be converted.
+type SelectorE func(item interface{}) (interface{}, error)
+
 // Predicate if a function that returns true if the predicate holds for the item.
 type Predicate func(item interface{}) bool
 
@@ -26,6 +38,15 @@ type Lesser func(a, b interface{}) bool
 // ManySelector converts 1 item into zero or more items
 type ManySelector func(interface{}) Iterable
 
+// Comparer compares 2 items, such that a<b:-1, a==b:0, a>b:1
+type Comparer func(a, b interface{}) int
+
+// JoinSelector combines an outer and an inner item into a result
+type JoinSelector func(outer, inner interface{}) interface{}
+
+// GroupJoinSelector combines an outer item and its inner items into a result
+type GroupJoinSelector func(outer interface{}, inner Iterable) interface{}
+
 // Iterator provides an iterator for the query results.
 func (q *Q) Iterator() Iterator {
 	return q.result.Iterator()
@@ -43,6 +64,28 @@ func (q *Q) Select(selector Selector) *Q {
 	return &Q{&selectIterable{q.result, selector}}
 }
 
+// WithContext stops the iteration of the query result when the context is done.
+// The operators that are applied to the returned query stop when they read
+// their next item. After the iteration has stopped, Err of the Iterator
+// returns the error of the context, see Err.
+func (q *Q) WithContext(ctx context.Context) *Q {
+	return &Q{&contextIterable{q.result, ctx}}
+}
+
+// WhereE filters the items using the filter function, like Where.
+// The iteration stops at the first error of the filter,
+// and Err of the Iterator returns that error, see Err.
+func (q *Q) WhereE(filter PredicateE) *Q {
+	return &Q{&whereEIterable{q.result, filter}}
+}
+
+// SelectE uses the selector to create a new result for each item, like Select.
+// The iteration stops at the first error of the selector,
+// and Err of the Iterator returns that error, see Err.
+func (q *Q) SelectE(selector SelectorE) *Q {
+	return &Q{&selectEIterable{q.result, selector}}
+}
+
 // SelectMany uses the selector to create an Iteratable containing zero or more
 // items for each item, and concatenates all the results.
 func (q *Q) SelectMany(selector ManySelector) *Q {
@@ -73,11 +116,49 @@ func (q *Q) GoBuffered(bufferSize int, action Action) {
 	GoBuffered(q, bufferSize, action)
 }
 
+// ToChannel sends the items in the query result to a new channel
+// on a separate goroutine, see ToChannel.
+func (q *Q) ToChannel(bufferSize int) (ch <-chan interface{}, cancel func()) {
+	return ToChannel(q, bufferSize)
+}
+
+// GoWait applies the action to every item in the query result on
+// the given number of worker goroutines, and waits for every action to complete.
+// See GoWait.
+func (q *Q) GoWait(workers int, action ErrorAction) error {
+	return GoWait(q, workers, action)
+}
+
+// GoBufferedWait applies the action to every item in the query result on
+// the given number of worker goroutines using a buffered channel of the supplied size,
+// and waits for every action to complete. See GoWait.
+func (q *Q) GoBufferedWait(workers, bufferSize int, action ErrorAction) error {
+	return GoBufferedWait(q, workers, bufferSize, action)
+}
+
 // ToSlice puts the query results in a new slice
 func (q *Q) ToSlice() []interface{} {
 	return ToSlice(q)
 }
 
+// ToSliceE puts the query results in a new slice,
+// returns the slice and the error that stopped the iteration, see Err.
+func (q *Q) ToSliceE() ([]interface{}, error) {
+	return ToSliceE(q)
+}
+
+// ToListE puts the query results in a new List,
+// returns the List and the error that stopped the iteration, see Err.
+func (q *Q) ToListE() (List, error) {
+	return ToListE(q)
+}
+
+// ForE applies the action to every item in the query result, and returns
+// the first error of the action or the error that stopped the iteration.
+func (q *Q) ForE(action ErrorAction) error {
+	return ForE(q, action)
+}
+
 // ToList puts the query results in a new List
 func (q *Q) ToList() List {
 	result, ok := q.result.(List)
@@ -107,11 +188,33 @@ func (q *Q) ToSet() Set {
 	return ToSet(q)
 }
 
+// ToMap puts the query results in a new Map, using the selectors
+// to compute the key and the value of every result.
+// If more than one result has the same key, the value of the last result is kept.
+func (q *Q) ToMap(keySelector, valueSelector Selector) Map {
+	return ToMap(q, keySelector, valueSelector)
+}
+
+// ToSortedList puts the query results in a new SortedList
+func (q *Q) ToSortedList(less Lesser) SortedList {
+	return ToSortedList(q, less)
+}
+
+// ToSortedSet puts the unique query results in a new SortedSet
+func (q *Q) ToSortedSet(less Lesser) SortedSet {
+	return ToSortedSet(q, less)
+}
+
 // ToQueue puts the unique query results in a new Queue
 func (q *Q) ToQueue() Queue {
 	return ToQueue(q)
 }
 
+// ToDeque puts the query results in a new Deque
+func (q *Q) ToDeque() Deque {
+	return ToDeque(q)
+}
+
 // ToStack puts the unique query results in a new Stack
 func (q *Q) ToStack() Stack {
 	return ToStack(q)
@@ -129,7 +232,9 @@ func (q *Q) Aggregate(aggregate interface{}, action Aggregator) interface{} {
 // First returns the first query result and true,
 // or nil and false if there are no results.
 func (q *Q) First() (interface{}, bool) {
-	for i := q.Iterator(); i.MoveNext(); {
+	i := q.Iterator()
+	defer Close(i)
+	for i.MoveNext() {
 		return i.Value(), true
 	}
 	return defaultElementValue, false
@@ -147,7 +252,9 @@ func (q *Q) Last() (interface{}, bool) {
 
 // Any returns true if there are results, false if there are not any results.
 func (q *Q) Any() bool {
-	for i := q.Iterator(); i.MoveNext(); {
+	i := q.Iterator()
+	defer Close(i)
+	for i.MoveNext() {
 		return true
 	}
 	return false
@@ -155,7 +262,9 @@ func (q *Q) Any() bool {
 
 // All returns true if the predicate holds for all results, false otherwise.
 func (q *Q) All(predicate Predicate) bool {
-	for i := q.Iterator(); i.MoveNext(); {
+	i := q.Iterator()
+	defer Close(i)
+	for i.MoveNext() {
 		if !predicate(i.Value()) {
 			return false
 		}
@@ -170,6 +279,18 @@ func (q *Q) Contains(item interface{}) bool {
 	}).Any()
 }
 
+// ContainsBy returns true if the query results contain the item
+// according to the Equality, false otherwise.
+// If eq is nil the DefaultEquality is used.
+func (q *Q) ContainsBy(item interface{}, eq Equality) bool {
+	if eq == nil {
+		eq = DefaultEquality
+	}
+	return q.Where(func(x interface{}) bool {
+		return eq.Equals(x, item)
+	}).Any()
+}
+
 // Count counts the number of results.
 func (q *Q) Count() int {
 	count := 0
@@ -207,6 +328,80 @@ func (q *Q) Concat(items Iterable) *Q {
 	return NewQuery(&concatIterable{q, items})
 }
 
+// GroupBy groups the results by the key computed by the keySelector.
+// The query result contains a Grouping for every key, in the order in which the
+// keys first appear, and every Grouping contains its items in source order.
+func (q *Q) GroupBy(keySelector Selector) *Q {
+	return &Q{IteratorFunc(func() Iterator {
+		i := q.Iterator()
+		return &errorIterator{toLookup(i, keySelector).Iterator(), Err(i)}
+	})}
+}
+
+// ToLookup puts the query results in a new Lookup,
+// indexed by the key computed by the keySelector.
+func (q *Q) ToLookup(keySelector Selector) Lookup {
+	return ToLookup(q, keySelector)
+}
+
+// Join correlates the results with the inner items that have the same key,
+// and uses the resultSelector to create a result for every matching pair.
+// Results without matching inner items are skipped.
+// The inner items are indexed once per iteration of the query.
+func (q *Q) Join(inner Iterable, outerKey, innerKey Selector, resultSelector JoinSelector) *Q {
+	return q.join(inner, innerKey, func(l Lookup) *Q {
+		return q.SelectMany(func(o interface{}) Iterable {
+			return NewQuery(l.Items(outerKey(o))).Select(func(i interface{}) interface{} {
+				return resultSelector(o, i)
+			})
+		})
+	})
+}
+
+// LeftJoin correlates the results with the inner items that have the same key,
+// and uses the resultSelector to create a result for every matching pair.
+// Results without matching inner items are passed to the
+// resultSelector once, with nil as the inner item.
+// The inner items are indexed once per iteration of the query.
+func (q *Q) LeftJoin(inner Iterable, outerKey, innerKey Selector, resultSelector JoinSelector) *Q {
+	return q.join(inner, innerKey, func(l Lookup) *Q {
+		return q.SelectMany(func(o interface{}) Iterable {
+			key := outerKey(o)
+			if !l.ContainsKey(key) {
+				return ListOf(resultSelector(o, defaultElementValue))
+			}
+			return NewQuery(l.Items(key)).Select(func(i interface{}) interface{} {
+				return resultSelector(o, i)
+			})
+		})
+	})
+}
+
+// GroupJoin correlates the results with the inner items that have the same key,
+// and uses the resultSelector to create a result for every outer result and
+// its, possibly empty, Iterable of matching inner items.
+// The inner items are indexed once per iteration of the query.
+func (q *Q) GroupJoin(inner Iterable, outerKey, innerKey Selector, resultSelector GroupJoinSelector) *Q {
+	return q.join(inner, innerKey, func(l Lookup) *Q {
+		return q.Select(func(o interface{}) interface{} {
+			return resultSelector(o, l.Items(outerKey(o)))
+		})
+	})
+}
+
+// join indexes the inner items on every iteration of the
+// query and iterates over the query created by the joiner.
+func (q *Q) join(inner Iterable, innerKey Selector, joiner func(Lookup) *Q) *Q {
+	return &Q{IteratorFunc(func() Iterator {
+		i := inner.Iterator()
+		l := toLookup(i, innerKey)
+		if err := Err(i); err != nil {
+			return &errorIterator{emptyIterator, err}
+		}
+		return joiner(l).Iterator()
+	})}
+}
+
 // Distinct filters non-unique items from the query result.
 func (q *Q) Distinct() *Q {
 	set := make(map[interface{}]bool)
@@ -220,6 +415,23 @@ func (q *Q) Distinct() *Q {
 	})
 }
 
+// DistinctBy filters non-unique items from the query result,
+// using the Equality to compare the items.
+// If eq is nil the DefaultEquality is used.
+func (q *Q) DistinctBy(eq Equality) *Q {
+	return &Q{&derivedIterable{q.result, func(i Iterator) Generate {
+		set := NewSetWith(eq)
+		return func() (interface{}, bool) {
+			for i.MoveNext() {
+				if set.Add(i.Value()) {
+					return i.Value(), true
+				}
+			}
+			return defaultElementValue, false
+		}
+	}}}
+}
+
 type concatIterable struct {
 	a, b Iterable
 }
@@ -237,11 +449,29 @@ func (i *concatIterator) Value() interface{} {
 	return i.value
 }
 
+func (i *concatIterator) Close() {
+	Close(i.a)
+	Close(i.b)
+	i.value = defaultElementValue
+}
+
+func (i *concatIterator) Err() error {
+	if err := Err(i.a); err != nil {
+		return err
+	}
+	return Err(i.b)
+}
+
 func (i *concatIterator) MoveNext() bool {
 	if i.a.MoveNext() {
 		i.value = i.a.Value()
 		return true
 	}
+	if Err(i.a) != nil {
+		// don't continue with b if a stopped because of an error
+		i.value = defaultElementValue
+		return false
+	}
 	if i.b.MoveNext() {
 		i.value = i.b.Value()
 		return true
@@ -274,18 +504,61 @@ func (q *Q) Skip(count int) *Q {
 
 // Sort sorts the result set using the lesser function.
 func (q *Q) Sort(lesser Lesser) *Q {
-	l := q.ToList()
+	l, err := q.toSortableList()
 	Sort(l, lesser)
+	return sorted(l, err)
+}
+
+// SortStable sorts the result set using the lesser function,
+// equal results keep their original order.
+func (q *Q) SortStable(lesser Lesser) *Q {
+	l, err := q.toSortableList()
+	SortStable(l, lesser)
+	return sorted(l, err)
+}
+
+// toSortableList returns the source List, so that it is sorted in place like
+// the List returned by ToList, or a new List of the results if the source is not a List.
+func (q *Q) toSortableList() (List, error) {
+	if l, ok := q.result.(List); ok {
+		return l, nil
+	}
+	return q.ToListE()
+}
+
+// sorted returns a query over the sorted list,
+// or a query without results that reports the error of the source.
+func sorted(l List, err error) *Q {
+	if err != nil {
+		return &Q{IteratorFunc(func() Iterator {
+			return &errorIterator{emptyIterator, err}
+		})}
+	}
 	return &Q{l}
 }
 
+// OrderBy sorts the results in ascending order of the key computed by the
+// keySelector, using compare to compare the keys.
+// The sort is stable, results with equal keys keep their original order.
+// The results are sorted on every iteration of the query.
+func (q *Q) OrderBy(keySelector Selector, compare Comparer) *OrderedQ {
+	return newOrderedQ(q, nil, orderKey{keySelector, compare, false})
+}
+
+// OrderByDescending sorts the results in descending order of the key
+// computed by the keySelector, using compare to compare the keys.
+// The sort is stable, results with equal keys keep their original order.
+// The results are sorted on every iteration of the query.
+func (q *Q) OrderByDescending(keySelector Selector, compare Comparer) *OrderedQ {
+	return newOrderedQ(q, nil, orderKey{keySelector, compare, true})
+}
+
 // Shuffle randomizes the order of the result set.
 func (q *Q) Shuffle() *Q {
-	return &Q{MakeIterable(func() Generate {
+	return &Q{&derivedIterable{q.result, func(i Iterator) Generate {
 		// make and fill the shuffle buffer
 		bufCap := 32
 		buf := make([]interface{}, 0, bufCap)
-		i := q.Iterator()
 		for len(buf) < bufCap && i.MoveNext() {
 			buf = append(buf, i.Value())
 		}
@@ -340,5 +613,5 @@ func (q *Q) Shuffle() *Q {
 			shuffleDone = len(buf) == 0
 			return value, true
 		}
-	})}
+	}}}
 }
@@ -127,6 +127,19 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortSortsSourceListInPlace(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	l := ListOf(3, 1, 2)
+	NewQuery(l).Sort(less)
+	assertIndexOf(t, l, 1, 0)
+	assertIndexOf(t, l, 3, 2)
+
+	l = ListOf(3, 1, 2)
+	NewQuery(l).SortStable(less)
+	assertIndexOf(t, l, 1, 0)
+	assertIndexOf(t, l, 3, 2)
+}
+
 func TestShuffle(t *testing.T) {
 	// smaller,equal and larger number of items
 	// than the internal buffer of Shuffle uses.
@@ -222,3 +235,66 @@ func testSource(t *testing.T) Iterable {
 	}
 	return source
 }
+
+type customer struct {
+	id   int
+	name string
+}
+
+type order struct {
+	customerId int
+	amount     int
+}
+
+var customers = ListOf(customer{1, "alice"}, customer{2, "bob"}, customer{3, "carol"})
+var orders = ListOf(order{1, 10}, order{1, 20}, order{3, 30}, order{4, 40})
+
+func customerId(c interface{}) interface{} { return c.(customer).id }
+func orderCustomerId(o interface{}) interface{} { return o.(order).customerId }
+
+func TestJoin(t *testing.T) {
+	result := NewQuery(customers).Join(orders, customerId, orderCustomerId,
+		func(c, o interface{}) interface{} {
+			return KeyValue{c.(customer).name, o.(order).amount}
+		}).ToSlice()
+
+	assert(t, 3, len(result), "len(result)")
+	assert(t, KeyValue{"alice", 10}, result[0], "result[0]")
+	assert(t, KeyValue{"alice", 20}, result[1], "result[1]")
+	assert(t, KeyValue{"carol", 30}, result[2], "result[2]")
+}
+
+func TestLeftJoin(t *testing.T) {
+	result := NewQuery(customers).LeftJoin(orders, customerId, orderCustomerId,
+		func(c, o interface{}) interface{} {
+			if o == nil {
+				return KeyValue{c.(customer).name, 0}
+			}
+			return KeyValue{c.(customer).name, o.(order).amount}
+		}).ToSlice()
+
+	assert(t, 4, len(result), "len(result)")
+	assert(t, KeyValue{"bob", 0}, result[2], "result[2]")
+}
+
+func TestGroupJoin(t *testing.T) {
+	orders := ToList(orders)
+	q := NewQuery(customers).GroupJoin(orders, customerId, orderCustomerId,
+		func(c interface{}, matches Iterable) interface{} {
+			total := NewQuery(matches).Aggregate(0, func(o, total interface{}) interface{} {
+				return o.(order).amount + total.(int)
+			})
+			return KeyValue{c.(customer).name, total}
+		})
+	result := q.ToSlice()
+
+	assert(t, 3, len(result), "len(result)")
+	assert(t, KeyValue{"alice", 30}, result[0], "result[0]")
+	assert(t, KeyValue{"bob", 0}, result[1], "result[1]")
+	assert(t, KeyValue{"carol", 30}, result[2], "result[2]")
+
+	// the inner items are indexed again on every iteration.
+	orders.Add(order{2, 5})
+	result = q.ToSlice()
+	assert(t, KeyValue{"bob", 5}, result[1], "result[1]")
+}
@@ -8,6 +8,10 @@ type queueIterator struct {
 }
 
 func (i *queueIterator) MoveNext() bool {
+	if i.q == nil {
+		return false
+	}
+
 	if i.version != i.q.version {
 		panic("Concurrent modification detected.")
 	}
@@ -21,8 +25,8 @@ func (i *queueIterator) MoveNext() bool {
 		i.e = i.q.head
 	} else {
 		i.e = i.e.next
-		i.done = i.e.next == nil
 	}
+	i.done = i.e == nil || i.e.next == nil
 	return i.e != nil
 }
 
@@ -32,3 +36,9 @@ func (i *queueIterator) Value() interface{} {
 	}
 	return i.e.item
 }
+
+func (i *queueIterator) Close() {
+	i.q = nil
+	i.e = nil
+	i.done = true
+}
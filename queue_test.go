@@ -73,6 +73,39 @@ func BenchmarkEnqDeq1000(b *testing.B) {
 	}
 }
 
+func BenchmarkConcurrentEnqDeq1000(b *testing.B) {
+	value := wrap(1)
+	q := NewConcurrentQueue()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			q.Enqueue(value)
+		}
+		for n := 0; n < 1000; n++ {
+			q.Dequeue()
+		}
+	}
+}
+
+func BenchmarkParallelEnqDeqSynchronized(b *testing.B) {
+	benchmarkParallelEnqDeq(b, SynchronizedQueue(NewQueue()))
+}
+
+func BenchmarkParallelEnqDeqConcurrent(b *testing.B) {
+	benchmarkParallelEnqDeq(b, NewConcurrentQueue())
+}
+
+func benchmarkParallelEnqDeq(b *testing.B, q Queue) {
+	value := wrap(1)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(value)
+			q.Dequeue()
+		}
+	})
+}
+
 func BenchmarkEnqConsume1000(b *testing.B) {
 	value := wrap(1)
 	q := NewQueue()
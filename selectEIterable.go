@@ -0,0 +1,10 @@
+package c3
+
+type selectEIterable struct {
+	items    Iterable
+	selector SelectorE
+}
+
+func (i *selectEIterable) Iterator() Iterator {
+	return &selectEIterator{items: i.items.Iterator(), selector: i.selector, value: defaultElementValue}
+}
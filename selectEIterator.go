@@ -0,0 +1,38 @@
+package c3
+
+type selectEIterator struct {
+	items    Iterator
+	selector SelectorE
+	value    interface{}
+	err      error
+}
+
+func (i *selectEIterator) MoveNext() bool {
+	if i.err == nil && i.items.MoveNext() {
+		value, err := i.selector(i.items.Value())
+		if err == nil {
+			i.value = value
+			return true
+		}
+		i.err = err
+		Close(i.items)
+	}
+	i.value = defaultElementValue
+	return false
+}
+
+func (i *selectEIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *selectEIterator) Close() {
+	Close(i.items)
+	i.value = defaultElementValue
+}
+
+func (i *selectEIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return Err(i.items)
+}
@@ -18,3 +18,12 @@ func (i *selectIterator) MoveNext() bool {
 func (i *selectIterator) Value() interface{} {
 	return i.value
 }
+
+func (i *selectIterator) Close() {
+	Close(i.items)
+	i.value = defaultElementValue
+}
+
+func (i *selectIterator) Err() error {
+	return Err(i.items)
+}
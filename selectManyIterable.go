@@ -11,5 +11,6 @@ func (i *selectManyIterable) Iterator() Iterator {
 		i.selector,
 		emptyIterator,
 		defaultElementValue,
+		nil,
 	}
 }
@@ -5,14 +5,18 @@ type selectManyIterator struct {
 	selector ManySelector
 	iterator Iterator
 	value    interface{}
+	err      error
 }
 
 func (i *selectManyIterator) MoveNext() bool {
+	if i.err != nil {
+		return false
+	}
 	if i.iterator.MoveNext() {
 		i.value = i.iterator.Value()
 		return true
 	}
-	for i.items.MoveNext() {
+	for i.err = Err(i.iterator); i.err == nil && i.items.MoveNext(); i.err = Err(i.iterator) {
 		value := i.items.Value()
 		i.iterator = i.selector(value).Iterator()
 		if !i.iterator.MoveNext() {
@@ -21,6 +25,10 @@ func (i *selectManyIterator) MoveNext() bool {
 		i.value = i.iterator.Value()
 		return true
 	}
+	if i.err != nil {
+		// stop at the error of an inner iterator
+		Close(i.items)
+	}
 	i.iterator = emptyIterator
 	i.value = defaultElementValue
 	return false
@@ -29,3 +37,17 @@ func (i *selectManyIterator) MoveNext() bool {
 func (i *selectManyIterator) Value() interface{} {
 	return i.value
 }
+
+func (i *selectManyIterator) Close() {
+	Close(i.iterator)
+	Close(i.items)
+	i.iterator = emptyIterator
+	i.value = defaultElementValue
+}
+
+func (i *selectManyIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return Err(i.items)
+}
@@ -0,0 +1,138 @@
+package c3
+
+import "iter"
+
+// Seq converts the Iterable into a range-over-func iterator.
+// The Iterator is closed when the range loop exits early.
+//
+// Usage:
+//
+//	for value := range c3.Seq(list) {
+//		...
+//	}
+func Seq(items Iterable) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		i := items.Iterator()
+		defer Close(i)
+		for i.MoveNext() {
+			if !yield(i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 converts the Iterable into a range-over-func iterator
+// that yields the index and the value of every item.
+// The Iterator is closed when the range loop exits early.
+//
+// Usage:
+//
+//	for index, value := range c3.Seq2(list) {
+//		...
+//	}
+func Seq2(items Iterable) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := items.Iterator()
+		defer Close(i)
+		for index := 0; i.MoveNext(); index++ {
+			if !yield(index, i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq converts the range-over-func iterator into an Iterable.
+// Every Iterator of the Iterable starts a new iteration of seq.
+//
+// Usage:
+//
+//	q := c3.NewQuery(c3.FromSeq(maps.Keys(m)))
+func FromSeq[T any](seq iter.Seq[T]) Iterable {
+	return &seqIterable{func() (func() (interface{}, bool), func()) {
+		next, stop := iter.Pull(seq)
+		return func() (interface{}, bool) {
+			return next()
+		}, stop
+	}}
+}
+
+// FromSeq2 converts the range-over-func iterator into an Iterable
+// of KeyValue items.
+// Every Iterator of the Iterable starts a new iteration of seq.
+//
+// Usage:
+//
+//	q := c3.NewQuery(c3.FromSeq2(maps.All(m)))
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) Iterable {
+	return &seqIterable{func() (func() (interface{}, bool), func()) {
+		next, stop := iter.Pull2(seq)
+		return func() (interface{}, bool) {
+			k, v, ok := next()
+			if !ok {
+				return defaultElementValue, false
+			}
+			return KeyValue{k, v}, true
+		}, stop
+	}}
+}
+
+// Seq converts the query into a range-over-func iterator.
+func (q *Q) Seq() iter.Seq[interface{}] {
+	return Seq(q)
+}
+
+// Seq2 converts the query into a range-over-func iterator
+// that yields the index and the value of every result.
+func (q *Q) Seq2() iter.Seq2[int, interface{}] {
+	return Seq2(q)
+}
+
+type seqIterable struct {
+	pull func() (next func() (interface{}, bool), stop func())
+}
+
+func (i *seqIterable) Iterator() Iterator {
+	return &seqIterator{pull: i.pull, value: defaultElementValue}
+}
+
+// seqIterator pulls the items from the sequence.
+// iter.Pull is only called on the first MoveNext
+// so an unused Iterator does not hold on to a goroutine.
+type seqIterator struct {
+	pull  func() (func() (interface{}, bool), func())
+	next  func() (interface{}, bool)
+	stop  func()
+	done  bool
+	value interface{}
+}
+
+func (i *seqIterator) MoveNext() bool {
+	if i.done {
+		return false
+	}
+	if i.next == nil {
+		i.next, i.stop = i.pull()
+	}
+	value, ok := i.next()
+	if ok {
+		i.value = value
+		return true
+	}
+	i.Close()
+	return false
+}
+
+func (i *seqIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *seqIterator) Close() {
+	if i.stop != nil {
+		i.stop()
+	}
+	i.pull, i.next, i.stop = nil, nil, nil
+	i.done = true
+	i.value = defaultElementValue
+}
@@ -0,0 +1,131 @@
+package c3
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	sum := 0
+	for v := range Seq(ListOf(1, 2, 3)) {
+		sum += v.(int)
+	}
+	assert(t, 6, sum, "sum")
+}
+
+func TestSeq2(t *testing.T) {
+	for index, v := range Seq2(QueueOf(0, 1, 2)) {
+		assert(t, index, v, "v")
+	}
+}
+
+func TestSeqOfSingleItemQueue(t *testing.T) {
+	q := NewQueue()
+	q.Enqueue(42)
+	count := 0
+	for v := range Seq(q) {
+		assert(t, 42, v, "v")
+		count++
+	}
+	assert(t, 1, count, "count")
+}
+
+// iteratorRecorder is an Iterable that remembers the Iterators it creates.
+type iteratorRecorder struct {
+	source    Iterable
+	mu        sync.Mutex
+	iterators []Iterator
+}
+
+func (r *iteratorRecorder) Iterator() Iterator {
+	i := r.source.Iterator()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.iterators = append(r.iterators, i)
+	return i
+}
+
+// released returns true if the container iterator released its container,
+// which it does when it is closed.
+func released(i Iterator) bool {
+	switch i := i.(type) {
+	case *listIterator:
+		return i.l == nil
+	case *queueIterator:
+		return i.q == nil
+	case *stackIterator:
+		return i.l == nil
+	}
+	return false
+}
+
+func TestSeqEarlyBreakClosesIterator(t *testing.T) {
+	for _, c := range []Iterable{ListOf(1, 2, 3), ToQueue(ListOf(1, 2, 3)), ToStack(ListOf(1, 2, 3))} {
+		r := &iteratorRecorder{source: c}
+		for range Seq(r) {
+			break
+		}
+		for range Seq2(r) {
+			break
+		}
+		assert(t, 2, len(r.iterators), "len(r.iterators)")
+		for _, i := range r.iterators {
+			assertb(t, true, released(i), "iterator closed after break")
+		}
+	}
+}
+
+func TestSeqEarlyBreakStopsPulledSeq(t *testing.T) {
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for _, v := range []int{1, 2, 3, 4} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for v := range NewQuery(FromSeq(seq)).Where(isMod2).Seq() {
+		assert(t, 2, v, "v")
+		break
+	}
+	assertb(t, true, stopped, "stopped")
+}
+
+func TestCloseIterator(t *testing.T) {
+	for _, c := range []Iterable{ListOf(1, 2), QueueOf(1, 2), StackOf(1, 2)} {
+		i := c.Iterator()
+		assertb(t, true, i.MoveNext(), "i.MoveNext()")
+		Close(i)
+		assertb(t, false, i.MoveNext(), "i.MoveNext() after Close()")
+		assert(t, nil, i.Value(), "i.Value() after Close()")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	q := NewQuery(FromSeq(maps.Keys(m)))
+	assert(t, 3, q.Count(), "q.Count()")
+	// the query can be run more than once
+	assertb(t, true, q.Contains("b"), `q.Contains("b")`)
+
+	first, ok := NewQuery(FromSeq(slices.Values([]int{4, 5, 6}))).First()
+	assertb(t, true, ok, "ok")
+	assert(t, 4, first, "first")
+}
+
+func TestFromSeq2(t *testing.T) {
+	kv, ok := NewQuery(FromSeq2(slices.All([]string{"a", "b"}))).Last()
+	assertb(t, true, ok, "ok")
+	assert(t, KeyValue{1, "b"}, kv, "kv")
+}
+
+func TestQuerySeq(t *testing.T) {
+	count := 0
+	for range QueryOf(1, 2, 3, 4).Where(isMod2).Seq() {
+		count++
+	}
+	assert(t, 2, count, "count")
+}
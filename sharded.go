@@ -0,0 +1,33 @@
+package c3
+
+// shardCount is the number of independently locked shards
+// of the concurrent containers, a power of 2.
+const shardCount = 32
+
+// shardOf returns the index of the shard of a hashcode.
+func shardOf(hash int) int {
+	return int(uint(hash) & (shardCount - 1))
+}
+
+// shardedIterable creates a weakly consistent Iterable over the items of the shards.
+// It takes a snapshot of a shard only when the iteration reaches that shard, so it
+// may or may not yield the items that are added or deleted while iterating.
+func shardedIterable(snapshot func(shard int) []interface{}) Iterable {
+	return MakeIterable(func() Generate {
+		shard := 0
+		var items []interface{}
+		return func() (interface{}, bool) {
+			for len(items) == 0 {
+				if shard == shardCount {
+					return defaultElementValue, false
+				}
+				items = snapshot(shard)
+				shard++
+			}
+			item := items[0]
+			items[0] = defaultElementValue
+			items = items[1:]
+			return item, true
+		}
+	})
+}
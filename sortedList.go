@@ -0,0 +1,71 @@
+package c3
+
+// sortedItems implements the methods that are
+// shared by the SortedList and the SortedSet.
+type sortedItems struct {
+	*tree
+}
+
+func (s *sortedItems) Iterator() Iterator {
+	return s.iterable(nodeItem).Iterator()
+}
+
+func (s *sortedItems) Delete(item interface{}) bool {
+	return s.remove(item)
+}
+
+func (s *sortedItems) Contains(item interface{}) bool {
+	return s.find(item) != nil
+}
+
+func (s *sortedItems) First() (interface{}, bool) {
+	return s.Get(0)
+}
+
+func (s *sortedItems) Last() (interface{}, bool) {
+	return s.Get(s.Len() - 1)
+}
+
+func (s *sortedItems) Get(index int) (interface{}, bool) {
+	return nodeItemOk(s.at(index))
+}
+
+func (s *sortedItems) IndexOf(item interface{}) (int, bool) {
+	return s.nextIndexOf(-1, item)
+}
+
+func (s *sortedItems) NextIndexOf(offset int, item interface{}) (int, bool) {
+	return s.nextIndexOf(offset, item)
+}
+
+func (s *sortedItems) LastIndexOf(item interface{}) (int, bool) {
+	return s.prevIndexOf(s.Len(), item)
+}
+
+func (s *sortedItems) PrevIndexOf(offset int, item interface{}) (int, bool) {
+	return s.prevIndexOf(offset, item)
+}
+
+func (s *sortedItems) Floor(item interface{}) (interface{}, bool) {
+	return nodeItemOk(s.floor(item))
+}
+
+func (s *sortedItems) Ceiling(item interface{}) (interface{}, bool) {
+	return nodeItemOk(s.ceiling(item))
+}
+
+func (s *sortedItems) Range(from, to interface{}) Iterable {
+	return s.rangeIterable(from, to, nodeItem)
+}
+
+func (s *sortedItems) Rank(item interface{}) int {
+	return s.rank(item)
+}
+
+type sortedList struct {
+	sortedItems
+}
+
+func (l *sortedList) Add(item interface{}) bool {
+	return l.insert(item, defaultElementValue, false)
+}
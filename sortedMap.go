@@ -0,0 +1,64 @@
+package c3
+
+type sortedMap struct {
+	*tree
+}
+
+func (m *sortedMap) Iterator() Iterator {
+	return m.Entries().Iterator()
+}
+
+func (m *sortedMap) Get(key interface{}) (interface{}, bool) {
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+	return defaultElementValue, false
+}
+
+func (m *sortedMap) Put(key, value interface{}) bool {
+	added := m.insert(key, value, true)
+	if !added {
+		m.version++
+	}
+	return added
+}
+
+func (m *sortedMap) Delete(key interface{}) bool {
+	return m.remove(key)
+}
+
+func (m *sortedMap) ContainsKey(key interface{}) bool {
+	return m.find(key) != nil
+}
+
+func (m *sortedMap) Keys() Iterable {
+	return m.iterable(nodeItem)
+}
+
+func (m *sortedMap) Values() Iterable {
+	return m.iterable(nodeValue)
+}
+
+func (m *sortedMap) Entries() Iterable {
+	return m.iterable(nodeEntry)
+}
+
+func (m *sortedMap) GetAt(index int) (KeyValue, bool) {
+	return nodeEntryOk(m.at(index))
+}
+
+func (m *sortedMap) Floor(key interface{}) (KeyValue, bool) {
+	return nodeEntryOk(m.floor(key))
+}
+
+func (m *sortedMap) Ceiling(key interface{}) (KeyValue, bool) {
+	return nodeEntryOk(m.ceiling(key))
+}
+
+func (m *sortedMap) Range(from, to interface{}) Iterable {
+	return m.rangeIterable(from, to, nodeEntry)
+}
+
+func (m *sortedMap) Rank(key interface{}) int {
+	return m.rank(key)
+}
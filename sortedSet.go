@@ -0,0 +1,55 @@
+package c3
+
+type sortedSet struct {
+	sortedItems
+}
+
+func (s *sortedSet) Add(item interface{}) bool {
+	return s.insert(item, defaultElementValue, true)
+}
+
+func (s *sortedSet) Intersection(other Set) Set {
+	result := NewSortedSet(s.less)
+	for i := other.Iterator(); i.MoveNext(); {
+		if s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *sortedSet) Difference(other Set) Set {
+	result := NewSortedSet(s.less)
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+func (s *sortedSet) Union(other Set) Set {
+	result := NewSortedSet(s.less)
+	for i := s.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	return result
+}
+
+func (s *sortedSet) SymmetricDifference(other Set) Set {
+	result := NewSortedSet(s.less)
+	for i := s.Iterator(); i.MoveNext(); {
+		if !other.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		if !s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
@@ -0,0 +1,171 @@
+package c3
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b interface{}) bool {
+	return a.(int) < b.(int)
+}
+
+func TestSortedListRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	l := NewSortedList(intLess)
+	var expected []int
+	for n := 0; n < 1000; n++ {
+		v := rnd.Intn(100)
+		if n%3 == 2 {
+			ok := l.Delete(v)
+			index := sort.SearchInts(expected, v)
+			found := index < len(expected) && expected[index] == v
+			assertb(t, found, ok, "l.Delete(v)")
+			if found {
+				expected = append(expected[:index], expected[index+1:]...)
+			}
+		} else {
+			l.Add(v)
+			expected = append(expected, v)
+			sort.Ints(expected)
+		}
+	}
+
+	assert(t, len(expected), l.Len(), "l.Len()")
+	index := 0
+	for i := l.Iterator(); i.MoveNext(); index++ {
+		if i.Value() != expected[index] {
+			failf(t, "expected %v at index %v, got %v", expected[index], index, i.Value())
+			return
+		}
+		item, _ := l.Get(index)
+		assert(t, expected[index], item, "l.Get(index)")
+	}
+	assertBalanced(t, l.(*sortedList).root)
+}
+
+func TestSortedListDuplicates(t *testing.T) {
+	l := ToSortedList(ListOf(3, 1, 2, 2, 2, 0), intLess)
+	assertIndexOf(t, l, 2, 2)
+	index, ok := l.LastIndexOf(2)
+	assertb(t, true, ok, "ok")
+	assert(t, 4, index, "index")
+	index, ok = l.NextIndexOf(2, 2)
+	assertb(t, true, ok, "ok")
+	assert(t, 3, index, "index")
+	index, ok = l.PrevIndexOf(2, 2)
+	assertb(t, false, ok, "ok")
+	assert(t, 2, l.Rank(2), "l.Rank(2)")
+	assert(t, 5, l.Rank(3), "l.Rank(3)")
+	assertContains(t, l, 4, false)
+}
+
+func TestSortedSetRangeQueries(t *testing.T) {
+	s := NewQuery(Range(0, 9)).
+		Select(func(v interface{}) interface{} { return v.(int) * 10 }).
+		ToSortedSet(intLess)
+	assertb(t, false, s.Add(50), "s.Add(50)")
+	assert(t, 10, s.Len(), "s.Len()")
+
+	floor, ok := s.Floor(35)
+	assertb(t, true, ok, "ok")
+	assert(t, 30, floor, "s.Floor(35)")
+	_, ok = s.Floor(-1)
+	assertb(t, false, ok, "ok")
+
+	ceiling, ok := s.Ceiling(35)
+	assertb(t, true, ok, "ok")
+	assert(t, 40, ceiling, "s.Ceiling(35)")
+	ceiling, _ = s.Ceiling(40)
+	assert(t, 40, ceiling, "s.Ceiling(40)")
+	_, ok = s.Ceiling(91)
+	assertb(t, false, ok, "ok")
+
+	r := ToSlice(s.Range(25, 60))
+	assert(t, 3, len(r), "len(r)")
+	assert(t, 30, r[0], "r[0]")
+	assert(t, 50, r[2], "r[2]")
+	assert(t, 0, len(ToSlice(s.Range(91, 100))), "len(empty range)")
+
+	assert(t, 3, s.Rank(30), "s.Rank(30)")
+	item, _ := s.Get(3)
+	assert(t, 30, item, "s.Get(3)")
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	a := ToSortedSet(ListOf(1, 2, 3), intLess)
+	b := SetOf(3, 4)
+	assert(t, 4, a.Union(b).Len(), "a.Union(b).Len()")
+	assert(t, 1, a.Intersection(b).Len(), "a.Intersection(b).Len()")
+	assert(t, 2, a.Difference(b).Len(), "a.Difference(b).Len()")
+	assert(t, 3, a.SymmetricDifference(b).Len(), "a.SymmetricDifference(b).Len()")
+
+	first, _ := a.Union(b).(SortedSet).First()
+	assert(t, 0, first, "first")
+}
+
+func TestSortedMap(t *testing.T) {
+	m := NewSortedMap(func(a, b interface{}) bool { return a.(string) < b.(string) })
+	assertb(t, true, m.Put("b", 2), `m.Put("b", 2)`)
+	assertb(t, true, m.Put("a", 1), `m.Put("a", 1)`)
+	assertb(t, true, m.Put("d", 4), `m.Put("d", 4)`)
+	assertb(t, false, m.Put("b", 3), `m.Put("b", 3)`)
+
+	value, _ := m.Get("b")
+	assert(t, 3, value, `m.Get("b")`)
+	keys := ToSlice(m.Keys())
+	assert(t, "a", keys[0], "keys[0]")
+	assert(t, "d", keys[2], "keys[2]")
+
+	kv, ok := m.Floor("c")
+	assertb(t, true, ok, "ok")
+	assert(t, KeyValue{"b", 3}, kv, `m.Floor("c")`)
+	kv, _ = m.Ceiling("c")
+	assert(t, KeyValue{"d", 4}, kv, `m.Ceiling("c")`)
+	kv, _ = m.GetAt(0)
+	assert(t, KeyValue{"a", 1}, kv, "m.GetAt(0)")
+	assert(t, 2, m.Rank("c"), `m.Rank("c")`)
+	assert(t, 2, NewQuery(m.Range("a", "c")).Count(), `m.Range("a", "c")`)
+
+	assertb(t, true, m.Delete("a"), `m.Delete("a")`)
+	assertb(t, false, m.ContainsKey("a"), `m.ContainsKey("a")`)
+	assert(t, 2, m.Len(), "m.Len()")
+}
+
+func TestSortedIteratorConcurrentModification(t *testing.T) {
+	l := ToSortedList(ListOf(1, 2, 3), intLess)
+	defer func() {
+		if recover() == nil {
+			fail(t, "expected a panic")
+		}
+	}()
+	for i := l.Iterator(); i.MoveNext(); {
+		l.Add(4)
+	}
+}
+
+func assertBalanced(t *testing.T, n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	l := assertBalanced(t, n.left)
+	r := assertBalanced(t, n.right)
+	if l-r > 1 || r-l > 1 {
+		failf(t, "unbalanced node %v: %v vs %v", n.item, l, r)
+	}
+	assert(t, size(n.left)+size(n.right)+1, n.size, "n.size")
+	return max(l, r) + 1
+}
+
+func BenchmarkSortedListAdd1000(b *testing.B) {
+	rnd := rand.New(rand.NewSource(42))
+	l := NewSortedList(intLess)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			l.Add(rnd.Int())
+		}
+		l.Clear()
+	}
+}
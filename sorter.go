@@ -2,6 +2,7 @@ package c3
 
 import "sort"
 
+// Sorter sorts a List with a Lesser function.
 type Sorter struct {
 	List
 	Lesser
@@ -16,3 +17,8 @@ func (s *Sorter) Less(i, j int) bool {
 func (s *Sorter) Sort() {
 	sort.Sort(s)
 }
+
+// SortStable sorts the List, equal items keep their original order.
+func (s *Sorter) SortStable() {
+	sort.Stable(s)
+}
@@ -8,6 +8,10 @@ type stackIterator struct {
 }
 
 func (si *stackIterator) MoveNext() bool {
+	if si.l == nil {
+		return false
+	}
+
 	if si.version != si.l.version {
 		si.value = defaultElementValue
 		panic("Concurrent modification detected")
@@ -26,3 +30,8 @@ func (si *stackIterator) MoveNext() bool {
 func (sc *stackIterator) Value() interface{} {
 	return sc.value
 }
+
+func (si *stackIterator) Close() {
+	si.l = nil
+	si.value = defaultElementValue
+}
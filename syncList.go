@@ -0,0 +1,126 @@
+package c3
+
+import "sync"
+
+// syncList is a List guarded by a sync.RWMutex.
+type syncList struct {
+	mu sync.RWMutex
+	l  List
+}
+
+func (l *syncList) Add(item interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Add(item)
+}
+
+func (l *syncList) AddIfAbsent(item interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.l.Contains(item) {
+		return false
+	}
+	return l.l.Add(item)
+}
+
+func (l *syncList) Delete(item interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Delete(item)
+}
+
+func (l *syncList) InsertAt(index int, item interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.InsertAt(index, item)
+}
+
+func (l *syncList) Swap(i, j int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Swap(i, j)
+}
+
+func (l *syncList) DeleteAt(index int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.DeleteAt(index)
+}
+
+func (l *syncList) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Clear()
+}
+
+func (l *syncList) Do(action func(l List)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	action(l.l)
+}
+
+func (l *syncList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Len()
+}
+
+func (l *syncList) Contains(item interface{}) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Contains(item)
+}
+
+func (l *syncList) First() (interface{}, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.First()
+}
+
+func (l *syncList) Get(index int) (interface{}, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Get(index)
+}
+
+func (l *syncList) Last() (interface{}, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Last()
+}
+
+func (l *syncList) IndexOf(item interface{}) (int, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.IndexOf(item)
+}
+
+func (l *syncList) PrevIndexOf(offset int, item interface{}) (int, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.PrevIndexOf(offset, item)
+}
+
+func (l *syncList) NextIndexOf(offset int, item interface{}) (int, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.NextIndexOf(offset, item)
+}
+
+func (l *syncList) LastIndexOf(item interface{}) (int, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.LastIndexOf(item)
+}
+
+func (l *syncList) Iterator() Iterator {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return snapshot(l.l)
+}
+
+// snapshot returns an Iterator over a copy of the items in the container,
+// which is not affected by later modifications of the container.
+func snapshot(c Iterable) Iterator {
+	return WrapList(ToSlice(c)).Iterator()
+}
@@ -0,0 +1,72 @@
+package c3
+
+import "sync"
+
+// syncQueue is a Queue guarded by a sync.RWMutex.
+type syncQueue struct {
+	mu sync.RWMutex
+	q  Queue
+}
+
+func (q *syncQueue) Enqueue(item interface{}) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.Enqueue(item)
+}
+
+func (q *syncQueue) Dequeue() (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.Dequeue()
+}
+
+func (q *syncQueue) DequeueIf(predicate Predicate) (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item, ok := q.q.Peek(); !ok || !predicate(item) {
+		return defaultElementValue, false
+	}
+	return q.q.Dequeue()
+}
+
+func (q *syncQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.Clear()
+}
+
+func (q *syncQueue) Do(action func(q Queue)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	action(q.q)
+}
+
+func (q *syncQueue) Peek() (interface{}, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Peek()
+}
+
+func (q *syncQueue) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Len()
+}
+
+func (q *syncQueue) Contains(item interface{}) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Contains(item)
+}
+
+func (q *syncQueue) Iterator() Iterator {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return snapshot(q.q)
+}
+
+// Consumer returns a consuming Iterator that dequeues
+// one item under the lock on every MoveNext.
+func (q *syncQueue) Consumer() Consumer {
+	return &dequeConsumer{q.Dequeue, defaultElementValue}
+}
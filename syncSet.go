@@ -0,0 +1,95 @@
+package c3
+
+import "sync"
+
+// syncSet is a Set guarded by a sync.RWMutex.
+type syncSet struct {
+	mu sync.RWMutex
+	s  Set
+}
+
+func (s *syncSet) Add(item interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Add(item)
+}
+
+func (s *syncSet) Delete(item interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Delete(item)
+}
+
+func (s *syncSet) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+func (s *syncSet) Do(action func(s Set)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action(s.s)
+}
+
+func (s *syncSet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+func (s *syncSet) Contains(item interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Contains(item)
+}
+
+func (s *syncSet) Iterator() Iterator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return snapshot(s.s)
+}
+
+// copy returns an unsynchronized copy of the set.
+func (s *syncSet) copy() Set {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Union(NewSet())
+}
+
+// unwrap returns a copy of other if it is a syncSet, so the set operations
+// never hold the locks of 2 sets at the same time.
+func unwrap(other Set) Set {
+	if o, ok := other.(*syncSet); ok {
+		return o.copy()
+	}
+	return other
+}
+
+func (s *syncSet) Union(other Set) Set {
+	other = unwrap(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Union(other)
+}
+
+func (s *syncSet) SymmetricDifference(other Set) Set {
+	other = unwrap(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.SymmetricDifference(other)
+}
+
+func (s *syncSet) Difference(other Set) Set {
+	other = unwrap(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Difference(other)
+}
+
+func (s *syncSet) Intersection(other Set) Set {
+	other = unwrap(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Intersection(other)
+}
@@ -0,0 +1,72 @@
+package c3
+
+import "sync"
+
+// syncStack is a Stack guarded by a sync.RWMutex.
+type syncStack struct {
+	mu sync.RWMutex
+	s  Stack
+}
+
+func (s *syncStack) Push(item interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Push(item)
+}
+
+func (s *syncStack) Pop() (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Pop()
+}
+
+func (s *syncStack) PopIf(predicate Predicate) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.s.Peek(); !ok || !predicate(item) {
+		return defaultElementValue, false
+	}
+	return s.s.Pop()
+}
+
+func (s *syncStack) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+func (s *syncStack) Do(action func(s Stack)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	action(s.s)
+}
+
+func (s *syncStack) Peek() (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Peek()
+}
+
+func (s *syncStack) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+func (s *syncStack) Contains(item interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Contains(item)
+}
+
+func (s *syncStack) Iterator() Iterator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return snapshot(s.s)
+}
+
+// Consumer returns a consuming Iterator that pops
+// one item under the lock on every MoveNext.
+func (s *syncStack) Consumer() Consumer {
+	return &dequeConsumer{s.Pop, defaultElementValue}
+}
@@ -0,0 +1,149 @@
+package c3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncListConcurrentAdd(t *testing.T) {
+	l := SynchronizedList(NewList())
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				l.Add(w*100 + n)
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert(t, 800, l.Len(), "l.Len()")
+}
+
+func TestSyncListAddIfAbsent(t *testing.T) {
+	l := SynchronizedList(NewList())
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				l.AddIfAbsent(n)
+			}
+		}()
+	}
+	wg.Wait()
+	assert(t, 100, l.Len(), "l.Len()")
+	assert(t, false, l.AddIfAbsent(5), "l.AddIfAbsent(5)")
+}
+
+func TestSyncListIterateWhileModifying(t *testing.T) {
+	l := SynchronizedList(ListOf(1, 2, 3))
+	count := 0
+	for i := l.Iterator(); i.MoveNext(); {
+		l.Add(i.Value().(int) * 2)
+		count++
+	}
+	assert(t, 3, count, "count")
+	assert(t, 6, l.Len(), "l.Len()")
+}
+
+func TestSyncListDo(t *testing.T) {
+	l := SynchronizedList(ListOf(1, 2, 3))
+	l.Do(func(l List) {
+		l.Swap(0, 2)
+		l.DeleteAt(1)
+	})
+	assertIndexOf(t, l, 3, 0)
+	assertIndexOf(t, l, 1, 1)
+}
+
+func TestSyncSetOperations(t *testing.T) {
+	a := SynchronizedSet(ToSet(ListOf(1, 2, 3)))
+	b := SynchronizedSet(ToSet(ListOf(2, 3, 4)))
+
+	assert(t, 4, a.Union(b).Len(), "Union")
+	assert(t, 2, a.Intersection(b).Len(), "Intersection")
+	assert(t, 1, a.Difference(b).Len(), "Difference")
+	assert(t, 2, a.SymmetricDifference(b).Len(), "SymmetricDifference")
+	assert(t, 3, a.Union(a).Len(), "Union with self")
+
+	if _, ok := a.Union(b).(SyncSet); ok {
+		fail(t, "expected a regular set")
+	}
+}
+
+func TestSyncQueueDequeueIf(t *testing.T) {
+	q := SynchronizedQueue(ToQueue(ListOf(1, 2, 3)))
+	isOdd := func(item interface{}) bool { return item.(int)%2 == 1 }
+
+	item, ok := q.DequeueIf(isOdd)
+	assert(t, true, ok, "ok")
+	assert(t, 1, item, "item")
+
+	item, ok = q.DequeueIf(isOdd)
+	assert(t, false, ok, "ok")
+	assert(t, nil, item, "item")
+	assert(t, 2, q.Len(), "q.Len()")
+
+	q.Clear()
+	_, ok = q.DequeueIf(isOdd)
+	assert(t, false, ok, "ok on empty queue")
+}
+
+func TestSyncQueueConcurrentConsumers(t *testing.T) {
+	q := SynchronizedQueue(NewQueue())
+	for n := 0; n < 1000; n++ {
+		q.Enqueue(n)
+	}
+
+	seen := SynchronizedSet(NewSet())
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := q.Consumer(); c.MoveNext(); {
+				if !seen.Add(c.Value()) {
+					failf(t, "item %v consumed twice", c.Value())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert(t, 1000, seen.Len(), "seen.Len()")
+	assert(t, 0, q.Len(), "q.Len()")
+}
+
+func TestSyncStackPopIf(t *testing.T) {
+	s := SynchronizedStack(NewStack())
+	s.Push(1)
+	s.Push(2)
+	isOdd := func(item interface{}) bool { return item.(int)%2 == 1 }
+
+	_, ok := s.PopIf(isOdd)
+	assert(t, false, ok, "ok")
+
+	item, ok := s.Pop()
+	assert(t, true, ok, "ok")
+	assert(t, 2, item, "item")
+
+	item, ok = s.PopIf(isOdd)
+	assert(t, true, ok, "ok")
+	assert(t, 1, item, "item")
+	assert(t, 0, s.Len(), "s.Len()")
+}
+
+func TestSyncStackIterateWhileConsuming(t *testing.T) {
+	s := SynchronizedStack(NewStack())
+	s.Push(1)
+	s.Push(2)
+	count := 0
+	for i := s.Iterator(); i.MoveNext(); {
+		s.Pop()
+		count++
+	}
+	assert(t, 2, count, "count")
+	assert(t, 0, s.Len(), "s.Len()")
+}
@@ -0,0 +1,321 @@
+package c3
+
+// tree is an AVL tree ordered by a Lesser function,
+// and every node keeps the size of its subtree for
+// the order statistics (Rank and Get by index).
+// Items are equal if neither is less than the other.
+type tree struct {
+	version int
+	root    *treeNode
+	less    Lesser
+}
+
+type treeNode struct {
+	item   interface{}
+	value  interface{}
+	left   *treeNode
+	right  *treeNode
+	height int
+	size   int
+}
+
+func newTree(less Lesser) *tree {
+	if less == nil {
+		panic("Lesser parameter invalid")
+	}
+	return &tree{0, nil, less}
+}
+
+func height(n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size(n *treeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treeNode) update() {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.size = size(n.left) + size(n.right) + 1
+}
+
+func (n *treeNode) rotateLeft() *treeNode {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *treeNode) rotateRight() *treeNode {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// balance restores the AVL property of the subtree and returns its new root
+func (n *treeNode) balance() *treeNode {
+	n.update()
+	switch height(n.left) - height(n.right) {
+	case 2:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case -2:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func (t *tree) equal(a, b interface{}) bool {
+	return !t.less(a, b) && !t.less(b, a)
+}
+
+func (t *tree) Len() int {
+	return size(t.root)
+}
+
+func (t *tree) Clear() {
+	if t.root == nil {
+		return
+	}
+	t.root = nil
+	t.version++
+}
+
+// insert adds the item, if unique is true and an equal item is present
+// only the value of the existing item is replaced.
+// Returns true if the item was added.
+func (t *tree) insert(item, value interface{}, unique bool) bool {
+	var added bool
+	t.root, added = t.insertAt(t.root, item, value, unique)
+	if added {
+		t.version++
+	}
+	return added
+}
+
+func (t *tree) insertAt(n *treeNode, item, value interface{}, unique bool) (*treeNode, bool) {
+	if n == nil {
+		return &treeNode{item, value, nil, nil, 1, 1}, true
+	}
+	var added bool
+	if t.less(item, n.item) {
+		n.left, added = t.insertAt(n.left, item, value, unique)
+	} else if unique && !t.less(n.item, item) {
+		n.value = value
+		return n, false
+	} else {
+		// equal items are added after the existing items
+		n.right, added = t.insertAt(n.right, item, value, unique)
+	}
+	return n.balance(), added
+}
+
+// remove removes an item that is equal to the item.
+// Returns true if an item was removed.
+func (t *tree) remove(item interface{}) bool {
+	var removed bool
+	t.root, removed = t.removeAt(t.root, item)
+	if removed {
+		t.version++
+	}
+	return removed
+}
+
+func (t *tree) removeAt(n *treeNode, item interface{}) (*treeNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	if t.less(item, n.item) {
+		n.left, removed = t.removeAt(n.left, item)
+	} else if t.less(n.item, item) {
+		n.right, removed = t.removeAt(n.right, item)
+	} else {
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		m := n.right
+		for m.left != nil {
+			m = m.left
+		}
+		n.item, n.value = m.item, m.value
+		n.right = removeFirst(n.right)
+		removed = true
+	}
+	return n.balance(), removed
+}
+
+// removeFirst removes the first node of the subtree and returns its new root
+func removeFirst(n *treeNode) *treeNode {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = removeFirst(n.left)
+	return n.balance()
+}
+
+// find returns the first node with an item equal to the item, or nil
+func (t *tree) find(item interface{}) *treeNode {
+	var result *treeNode
+	for n := t.root; n != nil; {
+		if t.less(n.item, item) {
+			n = n.right
+		} else {
+			if !t.less(item, n.item) {
+				result = n
+			}
+			n = n.left
+		}
+	}
+	return result
+}
+
+// floor returns the node with the greatest item less than or equal to the item, or nil
+func (t *tree) floor(item interface{}) *treeNode {
+	var result *treeNode
+	for n := t.root; n != nil; {
+		if t.less(item, n.item) {
+			n = n.left
+		} else {
+			result = n
+			n = n.right
+		}
+	}
+	return result
+}
+
+// ceiling returns the node with the least item greater than or equal to the item, or nil
+func (t *tree) ceiling(item interface{}) *treeNode {
+	var result *treeNode
+	for n := t.root; n != nil; {
+		if t.less(n.item, item) {
+			n = n.right
+		} else {
+			result = n
+			n = n.left
+		}
+	}
+	return result
+}
+
+// rank returns the number of items less than the item
+func (t *tree) rank(item interface{}) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		if t.less(n.item, item) {
+			rank += size(n.left) + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
+
+// upperRank returns the number of items less than or equal to the item
+func (t *tree) upperRank(item interface{}) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		if t.less(item, n.item) {
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// at returns the node at the index, or nil if the index is out of bounds
+func (t *tree) at(index int) *treeNode {
+	if 0 > index || index >= t.Len() {
+		return nil
+	}
+	n := t.root
+	for {
+		left := size(n.left)
+		switch {
+		case index < left:
+			n = n.left
+		case index > left:
+			index -= left + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// nextIndexOf returns the index of the next item equal to the item after the offset
+func (t *tree) nextIndexOf(offset int, item interface{}) (int, bool) {
+	index := max(t.rank(item), max(-1, offset)+1)
+	if index < t.upperRank(item) {
+		return index, true
+	}
+	return -1, false
+}
+
+// prevIndexOf returns the index of the next item equal to the item before the offset
+func (t *tree) prevIndexOf(offset int, item interface{}) (int, bool) {
+	index := min(t.upperRank(item), offset) - 1
+	if index >= 0 && index >= t.rank(item) {
+		return index, true
+	}
+	return -1, false
+}
+
+// iterable returns an Iterable of the selected values of all nodes, in order
+func (t *tree) iterable(selector func(n *treeNode) interface{}) Iterable {
+	return &treeIterable{t, selector, false, nil, nil}
+}
+
+// rangeIterable returns an Iterable of the selected values of the nodes
+// with items from the from item inclusive to the to item exclusive, in order
+func (t *tree) rangeIterable(from, to interface{}, selector func(n *treeNode) interface{}) Iterable {
+	return &treeIterable{t, selector, true, from, to}
+}
+
+func nodeItem(n *treeNode) interface{} {
+	return n.item
+}
+
+func nodeValue(n *treeNode) interface{} {
+	return n.value
+}
+
+func nodeEntry(n *treeNode) interface{} {
+	return KeyValue{n.item, n.value}
+}
+
+func nodeItemOk(n *treeNode) (interface{}, bool) {
+	if n == nil {
+		return defaultElementValue, false
+	}
+	return n.item, true
+}
+
+func nodeEntryOk(n *treeNode) (KeyValue, bool) {
+	if n == nil {
+		return KeyValue{}, false
+	}
+	return KeyValue{n.item, n.value}, true
+}
@@ -0,0 +1,73 @@
+package c3
+
+type treeIterable struct {
+	t        *tree
+	selector func(n *treeNode) interface{}
+	bounded  bool
+	from, to interface{}
+}
+
+func (i *treeIterable) Iterator() Iterator {
+	ti := &treeIterator{i.t, i.t.version, nil, i.selector, i.bounded, i.to, defaultElementValue}
+	// push the path to the first node on the stack
+	for n := i.t.root; n != nil; {
+		if i.bounded && i.t.less(n.item, i.from) {
+			n = n.right
+		} else {
+			ti.stack = append(ti.stack, n)
+			n = n.left
+		}
+	}
+	return ti
+}
+
+type treeIterator struct {
+	t        *tree
+	version  int
+	stack    []*treeNode
+	selector func(n *treeNode) interface{}
+	bounded  bool
+	to       interface{}
+	value    interface{}
+}
+
+func (i *treeIterator) MoveNext() bool {
+	if i.t == nil {
+		return false
+	}
+
+	if i.version != i.t.version {
+		i.value = defaultElementValue
+		panic("Concurrent modification detected")
+	}
+
+	last := len(i.stack) - 1
+	if last < 0 {
+		i.value = defaultElementValue
+		return false
+	}
+
+	n := i.stack[last]
+	if i.bounded && !i.t.less(n.item, i.to) {
+		i.stack = i.stack[:0]
+		i.value = defaultElementValue
+		return false
+	}
+
+	i.stack = i.stack[:last]
+	for x := n.right; x != nil; x = x.left {
+		i.stack = append(i.stack, x)
+	}
+	i.value = i.selector(n)
+	return true
+}
+
+func (i *treeIterator) Value() interface{} {
+	return i.value
+}
+
+func (i *treeIterator) Close() {
+	i.t = nil
+	i.stack = nil
+	i.value = defaultElementValue
+}
@@ -0,0 +1,367 @@
+package typed
+
+import "github.com/ReSc/c3"
+
+// The From* functions provide typed views of the interface{} based c3
+// containers, and the Untyped* functions provide c3 views of the typed
+// containers. The views share the underlying container, so modifications
+// through a view are visible in the original and vice versa.
+//
+// A typed view panics when it encounters an item that is not a T,
+// nil items are converted to the zero value of T.
+
+// FromIterable provides a typed view of a c3.Iterable.
+func FromIterable[T any](items c3.Iterable) Iterable[T] {
+	if u, ok := items.(*untypedIterable[T]); ok {
+		return u.items
+	}
+	return &typedIterable[T]{items}
+}
+
+// FromIterator provides a typed view of a c3.Iterator.
+func FromIterator[T any](i c3.Iterator) Iterator[T] {
+	if u, ok := i.(*untypedIterator[T]); ok {
+		return u.i
+	}
+	return &typedIterator[T]{i}
+}
+
+// FromList provides a typed view of a c3.List.
+func FromList[T any](l c3.List) List[T] {
+	if u, ok := l.(*untypedList[T]); ok {
+		return u.l
+	}
+	return &typedList[T]{l}
+}
+
+// FromSet provides a typed view of a c3.Set.
+func FromSet[T comparable](s c3.Set) Set[T] {
+	if u, ok := s.(*untypedSet[T]); ok {
+		return u.s
+	}
+	return &typedSet[T]{s}
+}
+
+// FromQueue provides a typed view of a c3.Queue.
+func FromQueue[T any](q c3.Queue) Queue[T] {
+	if u, ok := q.(*untypedQueue[T]); ok {
+		return u.q
+	}
+	return &typedQueue[T]{q}
+}
+
+// FromStack provides a typed view of a c3.Stack.
+func FromStack[T any](s c3.Stack) Stack[T] {
+	if u, ok := s.(*untypedStack[T]); ok {
+		return u.s
+	}
+	return &typedStack[T]{s}
+}
+
+// FromQuery converts a c3 query into a typed query.
+func FromQuery[T any](q *c3.Q) *Q[T] {
+	return NewQuery(FromIterable[T](q))
+}
+
+// UntypedIterable provides a c3.Iterable view of an Iterable.
+func UntypedIterable[T any](items Iterable[T]) c3.Iterable {
+	if t, ok := items.(*typedIterable[T]); ok {
+		return t.items
+	}
+	return &untypedIterable[T]{items}
+}
+
+// UntypedIterator provides a c3.Iterator view of an Iterator.
+func UntypedIterator[T any](i Iterator[T]) c3.Iterator {
+	if t, ok := i.(*typedIterator[T]); ok {
+		return t.i
+	}
+	return &untypedIterator[T]{i}
+}
+
+// UntypedList provides a c3.List view of a List.
+func UntypedList[T any](l List[T]) c3.List {
+	if t, ok := l.(*typedList[T]); ok {
+		return t.l
+	}
+	return &untypedList[T]{l}
+}
+
+// UntypedSet provides a c3.Set view of a Set.
+func UntypedSet[T comparable](s Set[T]) c3.Set {
+	if t, ok := s.(*typedSet[T]); ok {
+		return t.s
+	}
+	return &untypedSet[T]{s}
+}
+
+// UntypedQueue provides a c3.Queue view of a Queue.
+func UntypedQueue[T any](q Queue[T]) c3.Queue {
+	if t, ok := q.(*typedQueue[T]); ok {
+		return t.q
+	}
+	return &untypedQueue[T]{q}
+}
+
+// UntypedStack provides a c3.Stack view of a Stack.
+func UntypedStack[T any](s Stack[T]) c3.Stack {
+	if t, ok := s.(*typedStack[T]); ok {
+		return t.s
+	}
+	return &untypedStack[T]{s}
+}
+
+// Untyped converts the typed query into a c3 query.
+func (q *Q[T]) Untyped() *c3.Q {
+	return c3.NewQuery(UntypedIterable[T](q))
+}
+
+// cast converts an interface{} value into a T,
+// nil is converted into the zero value of T.
+func cast[T any](value interface{}) T {
+	if value == nil {
+		return zero[T]()
+	}
+	return value.(T)
+}
+
+// tryCast converts an interface{} value into a T and true,
+// or returns the zero value of T and false if the value is not a T.
+func tryCast[T any](value interface{}) (T, bool) {
+	if value == nil {
+		return zero[T](), true
+	}
+	result, ok := value.(T)
+	return result, ok
+}
+
+// castOk converts the result of a (interface{}, bool)
+// returning method into a (T, bool) result.
+func castOk[T any](value interface{}, ok bool) (T, bool) {
+	return cast[T](value), ok
+}
+
+// boxOk converts the result of a (T, bool) returning method
+// into an (interface{}, bool) result, using nil for missing values.
+func boxOk[T any](value T, ok bool) (interface{}, bool) {
+	if !ok {
+		return nil, false
+	}
+	return value, true
+}
+
+// indexOf calls the index function with item converted into a T,
+// or returns -1 and false if the item is not a T.
+func indexOf[T any](item interface{}, index func(T) (int, bool)) (int, bool) {
+	if value, ok := tryCast[T](item); ok {
+		return index(value)
+	}
+	return -1, false
+}
+
+// test calls the test function with item converted into a T,
+// or returns false if the item is not a T.
+func test[T any](item interface{}, test func(T) bool) bool {
+	if value, ok := tryCast[T](item); ok {
+		return test(value)
+	}
+	return false
+}
+
+//////////////////////////////
+// typed views of c3 values //
+//////////////////////////////
+
+type typedIterable[T any] struct {
+	items c3.Iterable
+}
+
+func (x *typedIterable[T]) Iterator() Iterator[T] {
+	return FromIterator[T](x.items.Iterator())
+}
+
+type typedIterator[T any] struct {
+	i c3.Iterator
+}
+
+func (x *typedIterator[T]) MoveNext() bool { return x.i.MoveNext() }
+func (x *typedIterator[T]) Value() T       { return cast[T](x.i.Value()) }
+
+type typedList[T any] struct {
+	l c3.List
+}
+
+func (x *typedList[T]) Iterator() Iterator[T]      { return FromIterator[T](x.l.Iterator()) }
+func (x *typedList[T]) Len() int                   { return x.l.Len() }
+func (x *typedList[T]) Contains(item T) bool       { return x.l.Contains(item) }
+func (x *typedList[T]) Clear()                     { x.l.Clear() }
+func (x *typedList[T]) Add(item T) bool            { return x.l.Add(item) }
+func (x *typedList[T]) Delete(item T) bool         { return x.l.Delete(item) }
+func (x *typedList[T]) First() (T, bool)           { return castOk[T](x.l.First()) }
+func (x *typedList[T]) Get(index int) (T, bool)    { return castOk[T](x.l.Get(index)) }
+func (x *typedList[T]) Last() (T, bool)            { return castOk[T](x.l.Last()) }
+func (x *typedList[T]) IndexOf(item T) (int, bool) { return x.l.IndexOf(item) }
+func (x *typedList[T]) LastIndexOf(item T) (int, bool) {
+	return x.l.LastIndexOf(item)
+}
+func (x *typedList[T]) PrevIndexOf(offset int, item T) (int, bool) {
+	return x.l.PrevIndexOf(offset, item)
+}
+func (x *typedList[T]) NextIndexOf(offset int, item T) (int, bool) {
+	return x.l.NextIndexOf(offset, item)
+}
+func (x *typedList[T]) InsertAt(index int, item T) bool { return x.l.InsertAt(index, item) }
+func (x *typedList[T]) Swap(i, j int)                   { x.l.Swap(i, j) }
+func (x *typedList[T]) DeleteAt(index int) bool         { return x.l.DeleteAt(index) }
+
+type typedSet[T comparable] struct {
+	s c3.Set
+}
+
+func (x *typedSet[T]) Iterator() Iterator[T] { return FromIterator[T](x.s.Iterator()) }
+func (x *typedSet[T]) Len() int              { return x.s.Len() }
+func (x *typedSet[T]) Contains(item T) bool  { return x.s.Contains(item) }
+func (x *typedSet[T]) Clear()                { x.s.Clear() }
+func (x *typedSet[T]) Add(item T) bool       { return x.s.Add(item) }
+func (x *typedSet[T]) Delete(item T) bool    { return x.s.Delete(item) }
+func (x *typedSet[T]) Union(other Set[T]) Set[T] {
+	return FromSet[T](x.s.Union(UntypedSet(other)))
+}
+func (x *typedSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+	return FromSet[T](x.s.SymmetricDifference(UntypedSet(other)))
+}
+func (x *typedSet[T]) Difference(other Set[T]) Set[T] {
+	return FromSet[T](x.s.Difference(UntypedSet(other)))
+}
+func (x *typedSet[T]) Intersection(other Set[T]) Set[T] {
+	return FromSet[T](x.s.Intersection(UntypedSet(other)))
+}
+
+type typedQueue[T any] struct {
+	q c3.Queue
+}
+
+func (x *typedQueue[T]) Iterator() Iterator[T] { return FromIterator[T](x.q.Iterator()) }
+func (x *typedQueue[T]) Len() int              { return x.q.Len() }
+func (x *typedQueue[T]) Contains(item T) bool  { return x.q.Contains(item) }
+func (x *typedQueue[T]) Clear()                { x.q.Clear() }
+func (x *typedQueue[T]) Peek() (T, bool)       { return castOk[T](x.q.Peek()) }
+func (x *typedQueue[T]) Consumer() Consumer[T] { return FromIterator[T](x.q.Consumer()) }
+func (x *typedQueue[T]) Enqueue(item T) bool   { return x.q.Enqueue(item) }
+func (x *typedQueue[T]) Dequeue() (T, bool)    { return castOk[T](x.q.Dequeue()) }
+
+type typedStack[T any] struct {
+	s c3.Stack
+}
+
+func (x *typedStack[T]) Iterator() Iterator[T] { return FromIterator[T](x.s.Iterator()) }
+func (x *typedStack[T]) Len() int              { return x.s.Len() }
+func (x *typedStack[T]) Contains(item T) bool  { return x.s.Contains(item) }
+func (x *typedStack[T]) Clear()                { x.s.Clear() }
+func (x *typedStack[T]) Peek() (T, bool)       { return castOk[T](x.s.Peek()) }
+func (x *typedStack[T]) Consumer() Consumer[T] { return FromIterator[T](x.s.Consumer()) }
+func (x *typedStack[T]) Push(item T) bool      { return x.s.Push(item) }
+func (x *typedStack[T]) Pop() (T, bool)        { return castOk[T](x.s.Pop()) }
+
+//////////////////////////////
+// c3 views of typed values //
+//////////////////////////////
+
+type untypedIterable[T any] struct {
+	items Iterable[T]
+}
+
+func (x *untypedIterable[T]) Iterator() c3.Iterator {
+	return UntypedIterator(x.items.Iterator())
+}
+
+type untypedIterator[T any] struct {
+	i Iterator[T]
+}
+
+func (x *untypedIterator[T]) MoveNext() bool     { return x.i.MoveNext() }
+func (x *untypedIterator[T]) Value() interface{} { return x.i.Value() }
+
+type untypedList[T any] struct {
+	l List[T]
+}
+
+func (x *untypedList[T]) Iterator() c3.Iterator             { return UntypedIterator(x.l.Iterator()) }
+func (x *untypedList[T]) Len() int                          { return x.l.Len() }
+func (x *untypedList[T]) Contains(item interface{}) bool    { return test(item, x.l.Contains) }
+func (x *untypedList[T]) Clear()                            { x.l.Clear() }
+func (x *untypedList[T]) Add(item interface{}) bool         { return x.l.Add(cast[T](item)) }
+func (x *untypedList[T]) Delete(item interface{}) bool      { return test(item, x.l.Delete) }
+func (x *untypedList[T]) First() (interface{}, bool)        { return boxOk(x.l.First()) }
+func (x *untypedList[T]) Get(index int) (interface{}, bool) { return boxOk(x.l.Get(index)) }
+func (x *untypedList[T]) Last() (interface{}, bool)         { return boxOk(x.l.Last()) }
+func (x *untypedList[T]) IndexOf(item interface{}) (int, bool) {
+	return indexOf(item, x.l.IndexOf)
+}
+func (x *untypedList[T]) LastIndexOf(item interface{}) (int, bool) {
+	return indexOf(item, x.l.LastIndexOf)
+}
+func (x *untypedList[T]) PrevIndexOf(offset int, item interface{}) (int, bool) {
+	return indexOf(item, func(value T) (int, bool) {
+		return x.l.PrevIndexOf(offset, value)
+	})
+}
+func (x *untypedList[T]) NextIndexOf(offset int, item interface{}) (int, bool) {
+	return indexOf(item, func(value T) (int, bool) {
+		return x.l.NextIndexOf(offset, value)
+	})
+}
+func (x *untypedList[T]) InsertAt(index int, item interface{}) bool {
+	return x.l.InsertAt(index, cast[T](item))
+}
+func (x *untypedList[T]) Swap(i, j int)           { x.l.Swap(i, j) }
+func (x *untypedList[T]) DeleteAt(index int) bool { return x.l.DeleteAt(index) }
+
+type untypedSet[T comparable] struct {
+	s Set[T]
+}
+
+func (x *untypedSet[T]) Iterator() c3.Iterator          { return UntypedIterator(x.s.Iterator()) }
+func (x *untypedSet[T]) Len() int                       { return x.s.Len() }
+func (x *untypedSet[T]) Contains(item interface{}) bool { return test(item, x.s.Contains) }
+func (x *untypedSet[T]) Clear()                         { x.s.Clear() }
+func (x *untypedSet[T]) Add(item interface{}) bool      { return x.s.Add(cast[T](item)) }
+func (x *untypedSet[T]) Delete(item interface{}) bool   { return test(item, x.s.Delete) }
+func (x *untypedSet[T]) Union(other c3.Set) c3.Set {
+	return UntypedSet(x.s.Union(FromSet[T](other)))
+}
+func (x *untypedSet[T]) SymmetricDifference(other c3.Set) c3.Set {
+	return UntypedSet(x.s.SymmetricDifference(FromSet[T](other)))
+}
+func (x *untypedSet[T]) Difference(other c3.Set) c3.Set {
+	return UntypedSet(x.s.Difference(FromSet[T](other)))
+}
+func (x *untypedSet[T]) Intersection(other c3.Set) c3.Set {
+	return UntypedSet(x.s.Intersection(FromSet[T](other)))
+}
+
+type untypedQueue[T any] struct {
+	q Queue[T]
+}
+
+func (x *untypedQueue[T]) Iterator() c3.Iterator          { return UntypedIterator(x.q.Iterator()) }
+func (x *untypedQueue[T]) Len() int                       { return x.q.Len() }
+func (x *untypedQueue[T]) Contains(item interface{}) bool { return test(item, x.q.Contains) }
+func (x *untypedQueue[T]) Clear()                         { x.q.Clear() }
+func (x *untypedQueue[T]) Peek() (interface{}, bool)      { return boxOk(x.q.Peek()) }
+func (x *untypedQueue[T]) Consumer() c3.Consumer          { return UntypedIterator[T](x.q.Consumer()) }
+func (x *untypedQueue[T]) Enqueue(item interface{}) bool  { return x.q.Enqueue(cast[T](item)) }
+func (x *untypedQueue[T]) Dequeue() (interface{}, bool)   { return boxOk(x.q.Dequeue()) }
+
+type untypedStack[T any] struct {
+	s Stack[T]
+}
+
+func (x *untypedStack[T]) Iterator() c3.Iterator          { return UntypedIterator(x.s.Iterator()) }
+func (x *untypedStack[T]) Len() int                       { return x.s.Len() }
+func (x *untypedStack[T]) Contains(item interface{}) bool { return test(item, x.s.Contains) }
+func (x *untypedStack[T]) Clear()                         { x.s.Clear() }
+func (x *untypedStack[T]) Peek() (interface{}, bool)      { return boxOk(x.s.Peek()) }
+func (x *untypedStack[T]) Consumer() c3.Consumer          { return UntypedIterator[T](x.s.Consumer()) }
+func (x *untypedStack[T]) Push(item interface{}) bool     { return x.s.Push(cast[T](item)) }
+func (x *untypedStack[T]) Pop() (interface{}, bool)       { return boxOk(x.s.Pop()) }
@@ -0,0 +1,76 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/ReSc/c3"
+)
+
+func TestFromList(t *testing.T) {
+	u := c3.ListOf(1, 2, 3)
+	l := FromList[int](u)
+
+	sum := 0
+	for i := l.Iterator(); i.MoveNext(); {
+		sum += i.Value()
+	}
+	assert(t, 6, sum, "sum")
+
+	l.Add(4)
+	assert(t, 4, u.Len(), "u.Len()")
+	assert(t, true, u.Contains(4), "u.Contains(4)")
+
+	assert(t, u, UntypedList(l), "UntypedList(l)")
+}
+
+func TestFromListWrongType(t *testing.T) {
+	l := FromList[int](c3.ListOf("a"))
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	l.First()
+}
+
+func TestUntypedList(t *testing.T) {
+	l := ListOf("a", "b")
+	u := UntypedList(l)
+
+	assert(t, true, u.Contains("b"), `u.Contains("b")`)
+	assert(t, false, u.Contains(42), "u.Contains(42)")
+
+	value, ok := u.Get(2)
+	assert(t, nil, value, "value")
+	assert(t, false, ok, "ok")
+
+	assert(t, 2, c3.NewQuery(u).Count(), "c3.NewQuery(u).Count()")
+	assert(t, l, FromList[string](u), "FromList(u)")
+}
+
+func TestUntypedSet(t *testing.T) {
+	a := UntypedSet(SetOf(1, 2))
+	b := c3.NewSet()
+	b.Add(2)
+	b.Add(3)
+	assert(t, 3, a.Union(b).Len(), "a.Union(b).Len()")
+	assert(t, 1, a.Intersection(b).Len(), "a.Intersection(b).Len()")
+}
+
+func TestQueueAndStackAdapters(t *testing.T) {
+	q := FromQueue[int](c3.NewQueue())
+	q.Enqueue(1)
+	q.Enqueue(2)
+	item, _ := q.Dequeue()
+	assert(t, 1, item, "q.Dequeue()")
+
+	s := UntypedStack(StackOf(1, 2))
+	item2, _ := s.Pop()
+	assert(t, 2, item2, "s.Pop()")
+}
+
+func TestQueryConversion(t *testing.T) {
+	q := FromQuery[int](c3.QueryOf(1, 2, 3)).
+		Where(func(v int) bool { return v > 1 })
+	assert(t, 2, q.Untyped().Count(), "q.Untyped().Count()")
+}
@@ -0,0 +1,205 @@
+// Package typed provides type-parameterized versions of the c3 containers
+// and the c3 query api.
+//
+// The containers provided:
+//   - Iterable[T]: a container that allow iterating over its items.
+//   - Bag[T]: an unordered container that allows duplicate items.
+//   - Set[T]: an unordered container that does not allow duplicate items.
+//   - List[T]: an indexable container.
+//   - Queue[T]: a fifo container.
+//   - Stack[T]: a lifo container.
+//
+// The containers have the same semantics as their c3 counterparts,
+// and the From* and Untyped* functions convert between the typed containers
+// and the interface{} based containers of package c3.
+package typed
+
+// Iterator provides a way to iterate over a container
+//
+// Usage:
+//
+//	for i := iterable.Iterator(); i.MoveNext(); {
+//		value := i.Value()
+//	}
+//
+// Iterator.Value() only return a valid value if the preceding call to
+// Iterator.MoveNext() returned true
+type Iterator[T any] interface {
+	// Move the iterator to the next item, returns true on succes
+	// or false if the are no more item
+	MoveNext() bool
+	// returns the value at the current iterator position, or the zero value
+	Value() T
+}
+
+// Iterable is a container of items that can be iterated over.
+type Iterable[T any] interface {
+	// returns a new Iterator positioned at the start of the container.
+	Iterator() Iterator[T]
+}
+
+// ReadOnlyBag provides a length and a test for
+// determining if an item is present in a container
+type ReadOnlyBag[T any] interface {
+	Iterable[T]
+	// Returns the item count
+	Len() int
+	// Returns true if the item is in the container, false otherwise.
+	Contains(item T) bool
+}
+
+// Indexable provides methods to get items from a container by index
+type Indexable[T any] interface {
+	// Returns the first item and true,
+	// or the zero value and false if there is no first item
+	First() (T, bool)
+	// Returns the item at the index and true,
+	// or the zero value and false if the index is out of bounds
+	Get(index int) (T, bool)
+	// Returns the last item and true,
+	// or the zero value and false if there is no last item
+	Last() (T, bool)
+	// Returns the first index of the item and true,
+	// or -1 and false if there is no such item
+	IndexOf(item T) (int, bool)
+	// Returns the index of the next item before the offset and true,
+	// or -1 and false if there is no such item
+	PrevIndexOf(offset int, item T) (int, bool)
+	// Returns the index of the next item after the offset and true,
+	// or -1 and false if there is no such item
+	NextIndexOf(offset int, item T) (int, bool)
+	// Returns the last index of the item and true,
+	// or -1 and false if there is no such item
+	LastIndexOf(item T) (int, bool)
+}
+
+// ReadOnlyList is an indexable readonly list
+type ReadOnlyList[T any] interface {
+	ReadOnlyBag[T]
+	Indexable[T]
+}
+
+// Bag is an unordered mutable container
+type Bag[T any] interface {
+	Clearer
+	ReadOnlyBag[T]
+	// Add adds an item to the container,
+	// returns true if the container was modified,
+	// false if it was not modified
+	Add(item T) bool
+	// Delete removes an item from the container,
+	// returns true if the container was modified,
+	// false if it was not modified
+	Delete(item T) bool
+}
+
+type List[T any] interface {
+	Bag[T]
+	Indexable[T]
+	// Inserts the item at the given index,
+	// returns true if the container was modified,
+	// false if it was not modified.
+	InsertAt(index int, item T) bool
+	// Swaps the 2 items at the given indexes
+	Swap(i, j int)
+	// Deletes the item at the given index,
+	// returns true if the container was modified,
+	// false if it was not modified.
+	DeleteAt(index int) bool
+}
+
+// A set type with basic set operations.
+// See also http://en.wikipedia.org/wiki/Set_theory
+type Set[T comparable] interface {
+	Bag[T]
+	// Union computes the union of the set.
+	// i.e. all items in this set and the other set, without duplicates
+	Union(other Set[T]) Set[T]
+	// SymmetricDifference computes the symmetric difference of the sets.
+	// i.e. all the items that are either in this set,
+	// or in the other set, but not in both.
+	SymmetricDifference(other Set[T]) Set[T]
+	// Difference computes the items that are in this set but not in the other set.
+	Difference(other Set[T]) Set[T]
+	// Intersection computes the items that are present in both sets.
+	Intersection(other Set[T]) Set[T]
+}
+
+// Peeker provides a method to look at the next item without removing it from the container.
+type Peeker[T any] interface {
+	// Peek returns the next item without removing it from the container.
+	Peek() (T, bool)
+}
+
+// Generator creates a new Generate function
+type Generator[T any] func() Generate[T]
+
+// Generate computes the next item in a sequence.
+// Returns the next item and true or the zero value and false if there are no more items.
+type Generate[T any] func() (T, bool)
+
+// Clearer provides a method to clear the container.
+type Clearer interface {
+	// Clear removes all items from the container.
+	Clear()
+}
+
+// Consumer is an Iterator that removes items from a container.
+type Consumer[T any] interface {
+	Iterator[T]
+}
+
+// Closer is an optional Iterator extension for iterators that hold
+// on to resources. See c3.Closer.
+type Closer interface {
+	Close()
+}
+
+// Consumable is a container that provides a consuming iterator.
+type Consumable[T any] interface {
+	Consumer() Consumer[T]
+}
+
+// A simple queuing container.
+type Queue[T any] interface {
+	ReadOnlyBag[T]
+	Peeker[T]
+	Clearer
+	Consumable[T]
+	// Appends an item at the tail of the queue,
+	// returns true if the queue was modified,
+	// false if it was not modified.
+	Enqueue(item T) bool
+	// Removes an item from the head of the queue,
+	// returns the item and true if the queue was modified,
+	// or the zero value and false if it was not modified.
+	Dequeue() (T, bool)
+}
+
+// A simple stack container
+type Stack[T any] interface {
+	ReadOnlyBag[T]
+	Peeker[T]
+	Clearer
+	Consumable[T]
+	// Adds an item at the top of the stack,
+	// returns true if the stack was modified,
+	// false if it was not modified.
+	Push(item T) bool
+	// Removes an item from the top of the stack,
+	// returns the item and true if the stack was modified,
+	// the zero value and false if it was not modified.
+	Pop() (T, bool)
+}
+
+// zero returns the zero value of T
+func zero[T any]() T {
+	var value T
+	return value
+}
+
+// equal compares 2 items the same way the c3 containers do,
+// i.e. by comparing them as interface{} values.
+func equal[T any](a, b T) bool {
+	return interface{}(a) == interface{}(b)
+}
@@ -0,0 +1,140 @@
+package typed
+
+// NewList creates a new, empty List.
+func NewList[T any]() List[T] {
+	return newList[T]()
+}
+
+// NewBag creates a new, empty Bag.
+func NewBag[T any]() Bag[T] {
+	return NewList[T]()
+}
+
+// NewQueue creates a new, empty Queue.
+func NewQueue[T any]() Queue[T] {
+	return &queue[T]{}
+}
+
+// NewSet creates a new, empty Set.
+func NewSet[T comparable]() Set[T] {
+	return &set[T]{0, make(map[T]bool)}
+}
+
+// NewStack creates a new, empty Stack.
+func NewStack[T any]() Stack[T] {
+	return &stack[T]{newList[T]()}
+}
+
+// NewQuery provides a entry point to the typed query api.
+//
+// Usage:
+//
+//	list := typed.ListOf(1,2,3)
+//	q := typed.NewQuery(list).
+//		Where(/* filter function here */).
+//		Select( /* selector function here */).
+//		ToList() /* collect the results */
+func NewQuery[T any](items Iterable[T]) *Q[T] {
+	return &Q[T]{items}
+}
+
+// QueryOf provides a entry point to the typed query api.
+//
+// Usage:
+//
+//	q := typed.QueryOf(1,2,3).
+//		    Where(/* filter function here */).
+//		    Select( /* selector function here */).
+//		    ToList() /* collect the results */
+func QueryOf[T any](items ...T) *Q[T] {
+	return NewQuery(IterableOf(items...))
+}
+
+// StackOf creates a new Stack with the given items.
+func StackOf[T any](items ...T) Stack[T] {
+	s := NewStack[T]()
+	for _, item := range items {
+		s.Push(item)
+	}
+	return s
+}
+
+// SetOf creates a new Set containing the unique items.
+func SetOf[T comparable](items ...T) Set[T] {
+	set := NewSet[T]()
+	for _, item := range items {
+		set.Add(item)
+	}
+	return set
+}
+
+// QueueOf creates a Queue with the given items.
+func QueueOf[T any](items ...T) Queue[T] {
+	q := NewQueue[T]()
+	for _, item := range items {
+		q.Enqueue(item)
+	}
+	return q
+}
+
+// ListOf creates a List with the given items.
+func ListOf[T any](items ...T) List[T] {
+	x := make([]T, len(items))
+	copy(x, items)
+	return &list[T]{0, x}
+}
+
+// MakeIterable converts the Generator function into an Iterable.
+func MakeIterable[T any](g Generator[T]) Iterable[T] {
+	return &generatorIterable[T]{g}
+}
+
+// MakeIterator converts the Generate function into an Iterator.
+func MakeIterator[T any](g Generate[T]) Iterator[T] {
+	return &generateIterator[T]{g, zero[T]()}
+}
+
+// MakeGenerator converts the Iterable into a Generator function.
+func MakeGenerator[T any](items Iterable[T]) Generator[T] {
+	return func() Generate[T] {
+		return MakeGenerate(items.Iterator())
+	}
+}
+
+// MakeGenerate converts the Iterator into a Generate function.
+func MakeGenerate[T any](i Iterator[T]) Generate[T] {
+	return func() (T, bool) {
+		ok := i.MoveNext()
+		value := i.Value()
+		return value, ok
+	}
+}
+
+// IterableOf creates an Iterable with the given items.
+func IterableOf[T any](items ...T) Iterable[T] {
+	return ListOf(items...)
+}
+
+// IteratorOf creates an Iterator with the given items.
+func IteratorOf[T any](items ...T) Iterator[T] {
+	return ListOf(items...).Iterator()
+}
+
+// ReadOnlyBagOf creates a ReadOnlyBag with the given items.
+func ReadOnlyBagOf[T any](items ...T) ReadOnlyBag[T] {
+	return ListOf(items...)
+}
+
+// ReadOnlyListOf creates a ReadOnlyList with the given items.
+func ReadOnlyListOf[T any](items ...T) ReadOnlyList[T] {
+	return ListOf(items...)
+}
+
+// BagOf creates a Bag with the given items.
+func BagOf[T any](items ...T) Bag[T] {
+	return ListOf(items...)
+}
+
+func newList[T any]() *list[T] {
+	return &list[T]{0, make([]T, 0, 4)}
+}
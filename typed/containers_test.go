@@ -0,0 +1,91 @@
+package typed
+
+import "testing"
+
+func TestList(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	assert(t, 3, l.Len(), "l.Len()")
+	assert(t, true, l.Contains(2), "l.Contains(2)")
+	assert(t, false, l.Contains(4), "l.Contains(4)")
+
+	index, ok := l.IndexOf(3)
+	assert(t, true, ok, "ok")
+	assert(t, 2, index, "index")
+
+	assert(t, true, l.InsertAt(0, 0), "l.InsertAt(0, 0)")
+	first, _ := l.First()
+	assert(t, 0, first, "first")
+
+	assert(t, true, l.Delete(2), "l.Delete(2)")
+	assert(t, []int{0, 1, 3}[2], ToSlice[int](l)[2], "ToSlice(l)[2]")
+
+	value, ok := l.Get(3)
+	assert(t, false, ok, "ok")
+	assert(t, 0, value, "value")
+}
+
+func TestListIteratorConcurrentModification(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	for i := l.Iterator(); i.MoveNext(); {
+		l.Add(i.Value())
+	}
+}
+
+func TestSet(t *testing.T) {
+	a := SetOf(1, 2, 3, 3)
+	b := SetOf(3, 4)
+	assert(t, 3, a.Len(), "a.Len()")
+	assert(t, false, a.Add(1), "a.Add(1)")
+
+	assert(t, 4, a.Union(b).Len(), "a.Union(b).Len()")
+	assert(t, 1, a.Intersection(b).Len(), "a.Intersection(b).Len()")
+	assert(t, 2, a.Difference(b).Len(), "a.Difference(b).Len()")
+	assert(t, 3, a.SymmetricDifference(b).Len(), "a.SymmetricDifference(b).Len()")
+
+	count := 0
+	for i := a.Iterator(); i.MoveNext(); {
+		count += i.Value()
+	}
+	assert(t, 6, count, "sum of items")
+}
+
+func TestQueue(t *testing.T) {
+	q := QueueOf("a")
+	index := 0
+	for i := q.Iterator(); i.MoveNext(); {
+		assert(t, "a", i.Value(), "i.Value()")
+		index++
+	}
+	assert(t, 1, index, "iterations")
+
+	q.Enqueue("b")
+	item, ok := q.Peek()
+	assert(t, "a", item, "q.Peek()")
+	assert(t, true, ok, "ok")
+
+	result := ""
+	for c := q.Consumer(); c.MoveNext(); {
+		result += c.Value()
+	}
+	assert(t, "ab", result, "consumed items")
+	assert(t, 0, q.Len(), "q.Len()")
+}
+
+func TestStack(t *testing.T) {
+	s := StackOf(1, 2, 3)
+	expected := 3
+	for i := s.Iterator(); i.MoveNext(); {
+		assert(t, expected, i.Value(), "i.Value()")
+		expected--
+	}
+
+	item, ok := s.Pop()
+	assert(t, 3, item, "s.Pop()")
+	assert(t, true, ok, "ok")
+	assert(t, 2, s.Len(), "s.Len()")
+}
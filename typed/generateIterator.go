@@ -0,0 +1,21 @@
+package typed
+
+type generateIterator[T any] struct {
+	g     Generate[T]
+	value T
+}
+
+func (i *generateIterator[T]) MoveNext() bool {
+	value, ok := i.g()
+	if ok {
+		i.value = value
+		return true
+	}
+
+	i.value = zero[T]()
+	return false
+}
+
+func (i *generateIterator[T]) Value() T {
+	return i.value
+}
@@ -0,0 +1,9 @@
+package typed
+
+type generatorIterable[T any] struct {
+	g Generator[T]
+}
+
+func (i *generatorIterable[T]) Iterator() Iterator[T] {
+	return &generateIterator[T]{i.g(), zero[T]()}
+}
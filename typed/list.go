@@ -0,0 +1,124 @@
+package typed
+
+type list[T any] struct {
+	version int
+	items   []T
+}
+
+func (l *list[T]) Iterator() Iterator[T] {
+	return &listIterator[T]{l, l.version, -1, zero[T]()}
+}
+
+func (l *list[T]) Add(item T) bool {
+	l.items = append(l.items, item)
+	l.version++
+	return true
+}
+
+func (l *list[T]) Swap(i, j int) {
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+	l.version++
+}
+
+func (l *list[T]) Clear() {
+	if l.Len() == 0 {
+		return
+	}
+	if l.Len() <= 1024 {
+		var empty T
+		for i := 0; i < len(l.items); i++ {
+			l.items[i] = empty
+		}
+		l.items = l.items[:0]
+	} else {
+		l.items = make([]T, 0, 4)
+	}
+	l.version++
+}
+
+func (l *list[T]) InsertAt(index int, item T) bool {
+	if 0 > index || index > len(l.items) {
+		return false
+	}
+
+	if index == len(l.items) {
+		return l.Add(item)
+	}
+
+	l.items = append(l.items, zero[T]())
+	copy(l.items[index+1:], l.items[index:])
+	l.items[index] = item
+	l.version++
+	return true
+}
+
+func (l *list[T]) First() (T, bool) {
+	return l.Get(0)
+}
+
+func (l *list[T]) Last() (T, bool) {
+	return l.Get(l.Len() - 1)
+}
+
+func (l *list[T]) Get(index int) (T, bool) {
+	if 0 > index || index >= len(l.items) {
+		return zero[T](), false
+	}
+	return l.items[index], true
+}
+
+func (l *list[T]) Contains(item T) bool {
+	_, ok := l.IndexOf(item)
+	return ok
+}
+
+func (l *list[T]) IndexOf(item T) (int, bool) {
+	return l.NextIndexOf(-1, item)
+}
+
+func (l *list[T]) NextIndexOf(offset int, item T) (int, bool) {
+	for index := max(-1, offset) + 1; 0 <= index && index < l.Len(); index++ {
+		if equal(l.items[index], item) {
+			return index, true
+		}
+	}
+	return -1, false
+}
+
+func (l *list[T]) LastIndexOf(item T) (int, bool) {
+	return l.PrevIndexOf(l.Len(), item)
+}
+
+func (l *list[T]) PrevIndexOf(offset int, item T) (int, bool) {
+	for index := min(offset, l.Len()) - 1; 0 <= index && index < l.Len(); index-- {
+		if equal(l.items[index], item) {
+			return index, true
+		}
+	}
+	return -1, false
+}
+
+func (l *list[T]) Delete(item T) bool {
+	if index, ok := l.IndexOf(item); ok {
+		return l.DeleteAt(index)
+	}
+	return false
+}
+
+func (l *list[T]) DeleteAt(index int) bool {
+	if 0 > index || index >= len(l.items) {
+		return false
+	}
+	last := len(l.items) - 1
+	if index != last {
+		copy(l.items[index:], l.items[index+1:])
+	}
+	l.items[last] = zero[T]()
+	l.items = l.items[:last]
+	l.version++
+	return true
+}
+
+func (l *list[T]) Len() int {
+	return len(l.items)
+}
@@ -0,0 +1,28 @@
+package typed
+
+type listIterator[T any] struct {
+	l       *list[T]
+	version int
+	index   int
+	value   T
+}
+
+func (i *listIterator[T]) MoveNext() bool {
+	if i.version != i.l.version {
+		i.value = zero[T]()
+		panic("Concurrent modification detected")
+	}
+
+	if i.index < len(i.l.items)-1 {
+		i.index++
+		i.value = i.l.items[i.index]
+		return true
+	}
+
+	i.value = zero[T]()
+	return false
+}
+
+func (i *listIterator[T]) Value() T {
+	return i.value
+}
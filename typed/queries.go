@@ -0,0 +1,355 @@
+package typed
+
+import "math/rand"
+import "time"
+
+// The typed query representation
+type Q[T any] struct {
+	result Iterable[T]
+}
+
+// Action is invoked for every item in the query result.
+type Action[T any] func(item T)
+
+// Predicate if a function that returns true if the predicate holds for the item.
+type Predicate[T any] func(item T) bool
+
+// Aggregator converts an item and an aggregate into an aggregate result
+type Aggregator[T, A any] func(item T, aggregate A) (aggregateResult A)
+
+// Selector converts an item into another item
+type Selector[T, R any] func(item T) R
+
+// Lesser compares 2 items, such that a<b:true, false otherwise
+type Lesser[T any] func(a, b T) bool
+
+// ManySelector converts 1 item into zero or more items
+type ManySelector[T, R any] func(T) Iterable[R]
+
+// Iterator provides an iterator for the query results.
+func (q *Q[T]) Iterator() Iterator[T] {
+	return q.result.Iterator()
+}
+
+// Filters the items using the filter function.
+// If filter returns true, the item is included
+// in the result, otherwise it is skipped.
+func (q *Q[T]) Where(filter Predicate[T]) *Q[T] {
+	return &Q[T]{&whereIterable[T]{q.result, filter}}
+}
+
+// Select uses the selector to create a new result for each item.
+// Use the Select function to select items of another type.
+func (q *Q[T]) Select(selector Selector[T, T]) *Q[T] {
+	return Select(q, selector)
+}
+
+// SelectMany uses the selector to create an Iteratable containing zero or more
+// items for each item, and concatenates all the results.
+// Use the SelectMany function to select items of another type.
+func (q *Q[T]) SelectMany(selector ManySelector[T, T]) *Q[T] {
+	return SelectMany(q, selector)
+}
+
+// Select uses the selector to create a new result of type R for each item.
+func Select[T, R any](q *Q[T], selector Selector[T, R]) *Q[R] {
+	return &Q[R]{&selectIterable[T, R]{q.result, selector}}
+}
+
+// SelectMany uses the selector to create an Iteratable containing zero or more
+// items of type R for each item, and concatenates all the results.
+func SelectMany[T, R any](q *Q[T], selector ManySelector[T, R]) *Q[R] {
+	return &Q[R]{&selectManyIterable[T, R]{q.result, selector}}
+}
+
+// For applies the action to every item in the query result.
+func (q *Q[T]) For(action Action[T]) {
+	For[T](q, action)
+}
+
+// Run runs the query and discards the results.
+func (q *Q[T]) Run() {
+	for i := q.Iterator(); i.MoveNext(); {
+		// do nothing.
+	}
+}
+
+// Go applies the action to every item in the query result on a
+// seperate goroutine using an unbuffered channel.
+func (q *Q[T]) Go(action Action[T]) {
+	Go[T](q, action)
+}
+
+// Go applies the action to every item in the query result on a
+// seperate goroutine using a buffered channel of the supplied size.
+func (q *Q[T]) GoBuffered(bufferSize int, action Action[T]) {
+	GoBuffered[T](q, bufferSize, action)
+}
+
+// ToSlice puts the query results in a new slice
+func (q *Q[T]) ToSlice() []T {
+	return ToSlice[T](q)
+}
+
+// ToList puts the query results in a new List
+func (q *Q[T]) ToList() List[T] {
+	result, ok := q.result.(List[T])
+	if ok {
+		return result
+	}
+	return ToList[T](q)
+}
+
+// ToReadOnlyList puts the query results in a new ReadOnlyList
+func (q *Q[T]) ToReadOnlyList() ReadOnlyList[T] {
+	return ToList[T](q)
+}
+
+// ToReadOnlyBag puts the query results in a new ReadOnlyBag
+func (q *Q[T]) ToReadOnlyBag() ReadOnlyBag[T] {
+	return ToList[T](q)
+}
+
+// ToBag puts the query results in a new Bag
+func (q *Q[T]) ToBag() Bag[T] {
+	return ToList[T](q)
+}
+
+// ToQueue puts the query results in a new Queue
+func (q *Q[T]) ToQueue() Queue[T] {
+	return ToQueue[T](q)
+}
+
+// ToStack puts the query results in a new Stack
+func (q *Q[T]) ToStack() Stack[T] {
+	return ToStack[T](q)
+}
+
+// Aggregate applies the action to every item in the query result
+// and combines them in a single result.
+// Use the Aggregate function to aggregate into another type.
+func (q *Q[T]) Aggregate(aggregate T, action Aggregator[T, T]) T {
+	return Aggregate(q, aggregate, action)
+}
+
+// Aggregate applies the action to every item in the query result
+// and combines them in a single result of type A.
+func Aggregate[T, A any](q *Q[T], aggregate A, action Aggregator[T, A]) A {
+	for i := q.Iterator(); i.MoveNext(); {
+		aggregate = action(i.Value(), aggregate)
+	}
+	return aggregate
+}
+
+// First returns the first query result and true,
+// or the zero value and false if there are no results.
+func (q *Q[T]) First() (T, bool) {
+	for i := q.Iterator(); i.MoveNext(); {
+		return i.Value(), true
+	}
+	return zero[T](), false
+}
+
+// Last returns the last query result and true,
+// or the zero value and false if there are no results.
+func (q *Q[T]) Last() (T, bool) {
+	value, ok := zero[T](), false
+	for i := q.Iterator(); i.MoveNext(); {
+		value, ok = i.Value(), true
+	}
+	return value, ok
+}
+
+// Any returns true if there are results, false if there are not any results.
+func (q *Q[T]) Any() bool {
+	for i := q.Iterator(); i.MoveNext(); {
+		return true
+	}
+	return false
+}
+
+// All returns true if the predicate holds for all results, false otherwise.
+func (q *Q[T]) All(predicate Predicate[T]) bool {
+	for i := q.Iterator(); i.MoveNext(); {
+		if !predicate(i.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains returns true if the query results contain the item, false otherwise.
+func (q *Q[T]) Contains(item T) bool {
+	return q.Where(func(x T) bool {
+		return equal(x, item)
+	}).Any()
+}
+
+// Count counts the number of results.
+func (q *Q[T]) Count() int {
+	count := 0
+	for i := q.Iterator(); i.MoveNext(); {
+		count++
+	}
+	return count
+}
+
+// Take truncates the results after count results have been computed.
+// If there are less results Take returns only the available results.
+func (q *Q[T]) Take(count int) *Q[T] {
+	taken := 0
+	return q.Where(func(v T) bool {
+		if taken >= count {
+			return false
+		}
+		taken++
+		return taken <= count
+	})
+}
+
+// Prepend prepends the items to the query result.
+func (q *Q[T]) Prepend(items ...T) *Q[T] {
+	return NewQuery(ListOf(items...)).Concat(q)
+}
+
+// Append appends the items to the query result.
+func (q *Q[T]) Append(items ...T) *Q[T] {
+	return q.Concat(ListOf(items...))
+}
+
+// Concat appends the items to the query result.
+func (q *Q[T]) Concat(items Iterable[T]) *Q[T] {
+	return NewQuery[T](&concatIterable[T]{q, items})
+}
+
+// Distinct filters non-unique items from the query result.
+func (q *Q[T]) Distinct() *Q[T] {
+	set := make(map[interface{}]bool)
+	return q.Where(func(v T) bool {
+		if !set[v] {
+			set[v] = true
+			return true
+		}
+
+		return false
+	})
+}
+
+type concatIterable[T any] struct {
+	a, b Iterable[T]
+}
+
+func (i *concatIterable[T]) Iterator() Iterator[T] {
+	return &concatIterator[T]{i.a.Iterator(), i.b.Iterator(), zero[T]()}
+}
+
+type concatIterator[T any] struct {
+	a, b  Iterator[T]
+	value T
+}
+
+func (i *concatIterator[T]) Value() T {
+	return i.value
+}
+
+func (i *concatIterator[T]) MoveNext() bool {
+	if i.a.MoveNext() {
+		i.value = i.a.Value()
+		return true
+	}
+	if i.b.MoveNext() {
+		i.value = i.b.Value()
+		return true
+	}
+	i.value = zero[T]()
+	return false
+}
+
+// Tee applies the action to every item in the query result
+// and passes every result on to the next query operator.
+func (q *Q[T]) Tee(action Action[T]) *Q[T] {
+	return q.Where(func(v T) bool {
+		action(v)
+		return true
+	})
+}
+
+// Skip skips the first count results and returns all results after that.
+// If there are less results Skip returns an empty result set.
+func (q *Q[T]) Skip(count int) *Q[T] {
+	skipped := 0
+	return q.Where(func(v T) bool {
+		if skipped >= count {
+			return true
+		}
+		skipped++
+		return skipped > count
+	})
+}
+
+// Sort sorts the result set using the lesser function.
+func (q *Q[T]) Sort(lesser Lesser[T]) *Q[T] {
+	l := ToList[T](q)
+	Sort(l, lesser)
+	return &Q[T]{l}
+}
+
+// Shuffle randomizes the order of the result set.
+func (q *Q[T]) Shuffle() *Q[T] {
+	return &Q[T]{MakeIterable(func() Generate[T] {
+		// make and fill the shuffle buffer
+		bufCap := 32
+		buf := make([]T, 0, bufCap)
+		i := q.Iterator()
+		for len(buf) < bufCap && i.MoveNext() {
+			buf = append(buf, i.Value())
+		}
+
+		// setup the iterator state
+		shuffleDone := len(buf) == 0
+		sourceDone := len(buf) < bufCap
+		if shuffleDone {
+			// just return
+			return func() (T, bool) {
+				return zero[T](), false
+			}
+		}
+
+		// setup the randomizer
+		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+		// return the Generate function.
+		return func() (T, bool) {
+
+			if shuffleDone {
+				return zero[T](), false
+			}
+
+			// get the value from the buffer
+			bufLen := len(buf)
+			index := rnd.Intn(bufLen)
+			value := buf[index]
+
+			// get the next value from the source
+			if !sourceDone {
+				if i.MoveNext() {
+					buf[index] = i.Value()
+					return value, true
+				}
+				sourceDone = true
+			}
+
+			// move the last buffer value into the current value's location
+			lastIndex := bufLen - 1
+			buf[index] = buf[lastIndex]
+
+			// clear the last value's location to help the
+			// garbage collector and then shorten the buffer
+			buf[lastIndex] = zero[T]()
+			buf = buf[:lastIndex]
+
+			shuffleDone = len(buf) == 0
+			return value, true
+		}
+	})}
+}
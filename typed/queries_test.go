@@ -0,0 +1,60 @@
+package typed
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestWhereSelect(t *testing.T) {
+	result := NewQuery(Range(1, 4)).
+		Where(func(v int) bool { return v%2 == 0 }).
+		Select(func(v int) int { return v * 10 }).
+		ToSlice()
+
+	assert(t, 2, len(result), "len(result)")
+	assert(t, 20, result[0], "result[0]")
+	assert(t, 40, result[1], "result[1]")
+}
+
+func TestSelectOtherType(t *testing.T) {
+	result := Select(QueryOf(1, 2, 3), strconv.Itoa).
+		Aggregate("", func(item, aggregate string) string {
+			return aggregate + item
+		})
+	assert(t, "123", result, "result")
+}
+
+func TestSelectMany(t *testing.T) {
+	count := SelectMany(QueryOf(1, 2), func(v int) Iterable[string] {
+		return Repeat(v, strconv.Itoa(v))
+	}).Count()
+	assert(t, 3, count, "count")
+}
+
+func TestDistinctSkipTake(t *testing.T) {
+	result := QueryOf(1, 1, 2, 3, 3, 4).Distinct().Skip(1).Take(2).ToSlice()
+	assert(t, 2, len(result), "len(result)")
+	assert(t, 2, result[0], "result[0]")
+	assert(t, 3, result[1], "result[1]")
+}
+
+func TestSortShuffle(t *testing.T) {
+	n := 100
+	l := NewQuery(Range(0, n-1)).
+		Shuffle().
+		Sort(func(a, b int) bool { return a < b }).
+		ToList()
+	assert(t, n, l.Len(), "l.Len()")
+	for i := 0; i < n; i++ {
+		v, _ := l.Get(i)
+		assert(t, i, v, "l.Get(i)")
+	}
+}
+
+func TestSeqRoundTrip(t *testing.T) {
+	sum := 0
+	for _, v := range NewQuery(FromSeq(Seq(Range(1, 4)))).Seq2() {
+		sum += v
+	}
+	assert(t, 10, sum, "sum")
+}
@@ -0,0 +1,113 @@
+package typed
+
+const (
+	// queue_max_free_length is an arbitratry large-ish number of free entries
+	// to keep around to prevent busywork for the garbage collector.
+	queue_max_free_length int = 1024
+)
+
+type queue[T any] struct {
+	head *entry[T]
+	tail *entry[T]
+	free *entry[T]
+
+	version    int
+	length     int
+	freeLength int
+}
+
+type entry[T any] struct {
+	item T
+	next *entry[T]
+}
+
+func (q *queue[T]) Len() int {
+	return q.length
+}
+
+func (q *queue[T]) Clear() {
+	if q.length == 0 {
+		return
+	}
+	q.head = nil
+	q.tail = nil
+	q.free = nil
+	q.length = 0
+	q.freeLength = 0
+	q.version++
+}
+
+func (q *queue[T]) Contains(item T) bool {
+	for e := q.head; e != nil; e = e.next {
+		if equal(e.item, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *queue[T]) Peek() (T, bool) {
+	if q.head != nil {
+		return q.head.item, true
+	}
+	return zero[T](), false
+}
+
+func (q *queue[T]) Dequeue() (T, bool) {
+	if q.head != nil {
+		// remove entry from queue
+		e := q.head
+		item := e.item
+		e.item = zero[T]()
+		q.head = e.next
+		if e.next == nil {
+			q.tail = nil
+		}
+
+		// add freed entry to free list but don't keep
+		// too many of them, it's a waste of space
+		if q.freeLength < queue_max_free_length {
+			e.next = q.free
+			q.free = e
+			q.freeLength++
+		}
+
+		q.length--
+		q.version++
+		return item, true
+	}
+	return zero[T](), false
+}
+
+func (q *queue[T]) Enqueue(item T) bool {
+	e := q.free
+	if e != nil {
+		// get entry from the free list
+		q.free = e.next
+		q.freeLength--
+		e.item = item
+		e.next = nil
+	} else {
+		// create a new entry
+		e = &entry[T]{item, nil}
+	}
+
+	if q.tail == nil {
+		q.head = e
+		q.tail = e
+	} else {
+		q.tail.next = e
+		q.tail = e
+	}
+	q.version++
+	q.length++
+	return true
+}
+
+func (q *queue[T]) Iterator() Iterator[T] {
+	return &queueIterator[T]{q, nil, false, q.version}
+}
+
+func (q *queue[T]) Consumer() Consumer[T] {
+	return &queueConsumer[T]{q, zero[T]()}
+}
@@ -0,0 +1,16 @@
+package typed
+
+type queueConsumer[T any] struct {
+	q    *queue[T]
+	item T
+}
+
+func (c *queueConsumer[T]) MoveNext() bool {
+	item, ok := c.q.Dequeue()
+	c.item = item
+	return ok
+}
+
+func (c *queueConsumer[T]) Value() T {
+	return c.item
+}
@@ -0,0 +1,34 @@
+package typed
+
+type queueIterator[T any] struct {
+	q       *queue[T]
+	e       *entry[T]
+	done    bool
+	version int
+}
+
+func (i *queueIterator[T]) MoveNext() bool {
+	if i.version != i.q.version {
+		panic("Concurrent modification detected.")
+	}
+
+	if i.done {
+		i.e = nil
+		return false
+	}
+
+	if i.e == nil {
+		i.e = i.q.head
+	} else {
+		i.e = i.e.next
+	}
+	i.done = i.e == nil || i.e.next == nil
+	return i.e != nil
+}
+
+func (i *queueIterator[T]) Value() T {
+	if i.e == nil {
+		return zero[T]()
+	}
+	return i.e.item
+}
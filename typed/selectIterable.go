@@ -0,0 +1,10 @@
+package typed
+
+type selectIterable[T, R any] struct {
+	items    Iterable[T]
+	selector Selector[T, R]
+}
+
+func (i *selectIterable[T, R]) Iterator() Iterator[R] {
+	return &selectIterator[T, R]{i.items.Iterator(), i.selector, zero[R]()}
+}
@@ -0,0 +1,20 @@
+package typed
+
+type selectIterator[T, R any] struct {
+	items    Iterator[T]
+	selector Selector[T, R]
+	value    R
+}
+
+func (i *selectIterator[T, R]) MoveNext() bool {
+	if i.items.MoveNext() {
+		i.value = i.selector(i.items.Value())
+		return true
+	}
+	i.value = zero[R]()
+	return false
+}
+
+func (i *selectIterator[T, R]) Value() R {
+	return i.value
+}
@@ -0,0 +1,15 @@
+package typed
+
+type selectManyIterable[T, R any] struct {
+	items    Iterable[T]
+	selector ManySelector[T, R]
+}
+
+func (i *selectManyIterable[T, R]) Iterator() Iterator[R] {
+	return &selectManyIterator[T, R]{
+		i.items.Iterator(),
+		i.selector,
+		EmptyIterator[R](),
+		zero[R](),
+	}
+}
@@ -0,0 +1,31 @@
+package typed
+
+type selectManyIterator[T, R any] struct {
+	items    Iterator[T]
+	selector ManySelector[T, R]
+	iterator Iterator[R]
+	value    R
+}
+
+func (i *selectManyIterator[T, R]) MoveNext() bool {
+	if i.iterator.MoveNext() {
+		i.value = i.iterator.Value()
+		return true
+	}
+	for i.items.MoveNext() {
+		value := i.items.Value()
+		i.iterator = i.selector(value).Iterator()
+		if !i.iterator.MoveNext() {
+			continue
+		}
+		i.value = i.iterator.Value()
+		return true
+	}
+	i.iterator = EmptyIterator[R]()
+	i.value = zero[R]()
+	return false
+}
+
+func (i *selectManyIterator[T, R]) Value() R {
+	return i.value
+}
@@ -0,0 +1,101 @@
+package typed
+
+import "iter"
+
+// Seq converts the Iterable into a range-over-func iterator.
+// The Iterator is closed when the range loop exits early.
+func Seq[T any](items Iterable[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := items.Iterator()
+		defer Close(i)
+		for i.MoveNext() {
+			if !yield(i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 converts the Iterable into a range-over-func iterator
+// that yields the index and the value of every item.
+// The Iterator is closed when the range loop exits early.
+func Seq2[T any](items Iterable[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := items.Iterator()
+		defer Close(i)
+		for index := 0; i.MoveNext(); index++ {
+			if !yield(index, i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq converts the range-over-func iterator into an Iterable.
+// Every Iterator of the Iterable starts a new iteration of seq.
+func FromSeq[T any](seq iter.Seq[T]) Iterable[T] {
+	return &seqIterable[T]{seq}
+}
+
+// Close closes the Iterator if it implements Closer.
+func Close[T any](i Iterator[T]) {
+	if c, ok := i.(Closer); ok {
+		c.Close()
+	}
+}
+
+// Seq converts the query into a range-over-func iterator.
+func (q *Q[T]) Seq() iter.Seq[T] {
+	return Seq[T](q)
+}
+
+// Seq2 converts the query into a range-over-func iterator
+// that yields the index and the value of every result.
+func (q *Q[T]) Seq2() iter.Seq2[int, T] {
+	return Seq2[T](q)
+}
+
+type seqIterable[T any] struct {
+	seq iter.Seq[T]
+}
+
+func (i *seqIterable[T]) Iterator() Iterator[T] {
+	return &seqIterator[T]{seq: i.seq}
+}
+
+type seqIterator[T any] struct {
+	seq   iter.Seq[T]
+	next  func() (T, bool)
+	stop  func()
+	done  bool
+	value T
+}
+
+func (i *seqIterator[T]) MoveNext() bool {
+	if i.done {
+		return false
+	}
+	if i.next == nil {
+		i.next, i.stop = iter.Pull(i.seq)
+	}
+	value, ok := i.next()
+	if ok {
+		i.value = value
+		return true
+	}
+	i.Close()
+	return false
+}
+
+func (i *seqIterator[T]) Value() T {
+	return i.value
+}
+
+func (i *seqIterator[T]) Close() {
+	if i.stop != nil {
+		i.stop()
+	}
+	i.seq, i.next, i.stop = nil, nil, nil
+	i.done = true
+	i.value = zero[T]()
+}
@@ -0,0 +1,131 @@
+package typed
+
+type set[T comparable] struct {
+	version int
+	items   map[T]bool
+}
+
+func (s *set[T]) Add(item T) bool {
+	if s.Contains(item) {
+		return false
+	}
+	s.items[item] = true
+	s.version++
+	return true
+}
+
+func (s *set[T]) Contains(item T) bool {
+	return s.items[item]
+}
+
+func (s *set[T]) Delete(item T) bool {
+	if s.Contains(item) {
+		delete(s.items, item)
+		s.version++
+		return true
+	}
+	return false
+}
+
+func (s *set[T]) Clear() {
+	if s.Len() == 0 {
+		return
+	}
+	s.items = make(map[T]bool)
+	s.version++
+}
+
+func (s *set[T]) Len() int {
+	return len(s.items)
+}
+
+func (s *set[T]) Iterator() Iterator[T] {
+	items := make([]T, 0, s.Len())
+	for k := range s.items {
+		items = append(items, k)
+	}
+	return &setIterator[T]{s, s.version, items, zero[T]()}
+}
+
+func (s *set[T]) Intersection(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	if os, ok := other.(*set[T]); ok {
+		// fast path
+		for item := range os.items {
+			if s.Contains(item) {
+				result.Add(item)
+			}
+		}
+	} else {
+		// slow path
+		for i := other.Iterator(); i.MoveNext(); {
+			if s.Contains(i.Value()) {
+				result.Add(i.Value())
+			}
+		}
+	}
+	return result
+}
+
+func (s *set[T]) Difference(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if !other.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+func (s *set[T]) Union(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		result.Add(item)
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		result.Add(i.Value())
+	}
+	return result
+}
+
+func (s *set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := NewSet[T]()
+	for item := range s.items {
+		if !other.Contains(item) {
+			result.Add(item)
+		}
+	}
+	for i := other.Iterator(); i.MoveNext(); {
+		if !s.Contains(i.Value()) {
+			result.Add(i.Value())
+		}
+	}
+	return result
+}
+
+type setIterator[T comparable] struct {
+	s       *set[T]
+	version int
+	items   []T
+	value   T
+}
+
+func (i *setIterator[T]) MoveNext() bool {
+	if i.version != i.s.version {
+		i.value = zero[T]()
+		panic("Concurrent modification detected")
+	}
+
+	if len(i.items) == 0 {
+		i.value = zero[T]()
+		return false
+	}
+
+	i.value = i.items[0]
+	i.items = i.items[1:]
+	return true
+}
+
+func (i *setIterator[T]) Value() T {
+	return i.value
+}
@@ -0,0 +1,41 @@
+package typed
+
+type stack[T any] struct {
+	l *list[T]
+}
+
+func (s *stack[T]) Len() int {
+	return s.l.Len()
+}
+
+func (s *stack[T]) Clear() {
+	s.l.Clear()
+}
+
+func (s *stack[T]) Peek() (T, bool) {
+	return s.l.Last()
+}
+
+func (s *stack[T]) Pop() (T, bool) {
+	item, ok := s.l.Last()
+	if ok {
+		s.l.DeleteAt(s.Len() - 1)
+	}
+	return item, ok
+}
+
+func (s *stack[T]) Contains(item T) bool {
+	return s.l.Contains(item)
+}
+
+func (s *stack[T]) Push(item T) bool {
+	return s.l.Add(item)
+}
+
+func (s *stack[T]) Iterator() Iterator[T] {
+	return &stackIterator[T]{s.l, s.l.Len(), s.l.version, zero[T]()}
+}
+
+func (s *stack[T]) Consumer() Consumer[T] {
+	return &stackConsumer[T]{s, zero[T]()}
+}
@@ -0,0 +1,20 @@
+package typed
+
+type stackConsumer[T any] struct {
+	s     Stack[T]
+	value T
+}
+
+func (sc *stackConsumer[T]) MoveNext() bool {
+	value, ok := sc.s.Pop()
+	if ok {
+		sc.value = value
+		return true
+	}
+	sc.value = zero[T]()
+	return false
+}
+
+func (sc *stackConsumer[T]) Value() T {
+	return sc.value
+}
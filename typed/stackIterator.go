@@ -0,0 +1,28 @@
+package typed
+
+type stackIterator[T any] struct {
+	l       *list[T]
+	index   int
+	version int
+	value   T
+}
+
+func (si *stackIterator[T]) MoveNext() bool {
+	if si.version != si.l.version {
+		si.value = zero[T]()
+		panic("Concurrent modification detected")
+	}
+
+	si.index--
+
+	if si.index >= 0 {
+		si.value = si.l.items[si.index]
+		return true
+	}
+	si.value = zero[T]()
+	return false
+}
+
+func (si *stackIterator[T]) Value() T {
+	return si.value
+}
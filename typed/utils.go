@@ -0,0 +1,165 @@
+package typed
+
+import "sort"
+
+// EmptyIterable returns an empty Iterable.
+func EmptyIterable[T any]() Iterable[T] {
+	return &nilIterable[T]{}
+}
+
+// EmptyIterator returns an empty Iterator.
+func EmptyIterator[T any]() Iterator[T] {
+	return &nilIterator[T]{}
+}
+
+type nilIterable[T any] struct{}
+
+func (x *nilIterable[T]) Iterator() Iterator[T] {
+	return &nilIterator[T]{}
+}
+
+type nilIterator[T any] struct{}
+
+func (x *nilIterator[T]) MoveNext() bool { return false }
+
+func (x *nilIterator[T]) Value() T { return zero[T]() }
+
+// Sort sorts the list with the given Lesser function
+func Sort[T any](l List[T], lesser Lesser[T]) {
+	sort.Sort(&sorter[T]{l, lesser})
+}
+
+type sorter[T any] struct {
+	List[T]
+	Lesser[T]
+}
+
+func (s *sorter[T]) Less(i, j int) bool {
+	iv, _ := s.Get(i)
+	jv, _ := s.Get(j)
+	return s.Lesser(iv, jv)
+}
+
+// ToSlice makes a new slice of the items in an Iterable
+func ToSlice[T any](c Iterable[T]) []T {
+	var slice []T
+	if col, ok := c.(ReadOnlyBag[T]); ok {
+		slice = make([]T, 0, col.Len())
+	} else {
+		slice = make([]T, 0, 4)
+	}
+	for i := c.Iterator(); i.MoveNext(); {
+		slice = append(slice, i.Value())
+	}
+	return slice
+}
+
+// ToList creates a new List of the items in an Iterable
+func ToList[T any](c Iterable[T]) List[T] {
+	l := NewList[T]()
+	for i := c.Iterator(); i.MoveNext(); {
+		l.Add(i.Value())
+	}
+	return l
+}
+
+// ToSet makes a new Set of the unique items in an Iterable
+func ToSet[T comparable](c Iterable[T]) Set[T] {
+	set := NewSet[T]()
+	for i := c.Iterator(); i.MoveNext(); {
+		set.Add(i.Value())
+	}
+	return set
+}
+
+// ToStack makes a new Stack of the items in an Iterable
+func ToStack[T any](c Iterable[T]) Stack[T] {
+	s := NewStack[T]()
+	for i := c.Iterator(); i.MoveNext(); {
+		s.Push(i.Value())
+	}
+	return s
+}
+
+// ToQueue makes a new Queue of the items in an Iterable
+func ToQueue[T any](c Iterable[T]) Queue[T] {
+	s := NewQueue[T]()
+	for i := c.Iterator(); i.MoveNext(); {
+		s.Enqueue(i.Value())
+	}
+	return s
+}
+
+// For applies the action to each item in the Iterable
+func For[T any](c Iterable[T], action Action[T]) {
+	for i := c.Iterator(); i.MoveNext(); {
+		action(i.Value())
+	}
+}
+
+// Go applies the action to each item in the Iterable
+// on a separate goroutine using an unbuffered channel
+func Go[T any](c Iterable[T], action Action[T]) {
+	GoBuffered(c, 0, action)
+}
+
+// GoBuffered applies the action to each item in the Iterable
+// on a separate goroutine using a buffered channel
+func GoBuffered[T any](c Iterable[T], bufferSize int, action Action[T]) {
+	ch := make(chan T, bufferSize)
+	defer close(ch)
+	go func() {
+		for value := range ch {
+			action(value)
+		}
+	}()
+	For(c, func(value T) { ch <- value })
+}
+
+// Repeat repeats the item count times.
+//
+// e.g.:
+//
+//	Repeat(3,42) // returns [42,42,42]
+func Repeat[T any](count int, item T) Iterable[T] {
+	if count < 0 {
+		panic("Count parameter invalid")
+	}
+	if count == 0 {
+		return EmptyIterable[T]()
+	}
+	return MakeIterable(func() Generate[T] {
+		x := 0
+		return func() (T, bool) {
+			if x == count {
+				return zero[T](), false
+			}
+			x++
+			return item, true
+		}
+	})
+}
+
+// Range creates a range iterable that iterates over the ints from start to end inclusive.
+//
+// e.g.:
+//
+//	Range(0,9) // returns [0,1,2,3,4,5,6,7,8,9]
+//	Range(9,0) // returns [9,8,7,6,5,4,3,2,1,0]
+func Range(start, end int) Iterable[int] {
+	inc := 1
+	if end < start {
+		inc = -1
+	}
+	return MakeIterable(func() Generate[int] {
+		x := start - inc
+		y := end
+		return func() (int, bool) {
+			if x == y {
+				return 0, false
+			}
+			x = x + inc
+			return x, true
+		}
+	})
+}
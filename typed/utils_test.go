@@ -0,0 +1,43 @@
+package typed
+
+import (
+	"path"
+	"runtime"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	s := ToSlice(Range(0, 3))
+	for n := 0; n < 4; n++ {
+		assert(t, n, s[n], "s[n]")
+	}
+	s = ToSlice(Range(3, 0))
+	assert(t, 4, len(s), "len(s)")
+	assert(t, 3, s[0], "s[0]")
+}
+
+func TestRepeat(t *testing.T) {
+	s := ToSlice(Repeat(3, "x"))
+	assert(t, 3, len(s), "len(s)")
+	assert(t, "x", s[2], "s[2]")
+}
+
+func TestSortList(t *testing.T) {
+	l := ListOf(3, 1, 0, 2)
+	Sort(l, func(a, b int) bool { return a < b })
+	for i := 0; i < l.Len(); i++ {
+		v, _ := l.Get(i)
+		assert(t, i, v, "l.Get(i)")
+	}
+}
+
+///////////////////////////////
+// testing utility functions //
+///////////////////////////////
+
+func assert(t *testing.T, expected, actual interface{}, msg string) {
+	if expected != actual {
+		_, file, line, _ := runtime.Caller(1)
+		t.Errorf("\n%v:%v: Expected %v, got %v for %v.", path.Base(file), line, expected, actual, msg)
+	}
+}
@@ -0,0 +1,10 @@
+package typed
+
+type whereIterable[T any] struct {
+	items Iterable[T]
+	where Predicate[T]
+}
+
+func (i *whereIterable[T]) Iterator() Iterator[T] {
+	return &whereIterator[T]{i.items.Iterator(), i.where}
+}
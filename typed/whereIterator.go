@@ -0,0 +1,19 @@
+package typed
+
+type whereIterator[T any] struct {
+	items Iterator[T]
+	where Predicate[T]
+}
+
+func (i *whereIterator[T]) MoveNext() bool {
+	for i.items.MoveNext() {
+		if i.where(i.items.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *whereIterator[T]) Value() T {
+	return i.items.Value()
+}
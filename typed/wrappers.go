@@ -0,0 +1,26 @@
+package typed
+
+// WrapConsumer wraps a channel in a consuming Iterator
+func WrapConsumer[T any](c <-chan T) Consumer[T] {
+	return &consumer[T]{c, false, zero[T]()}
+}
+
+// Wraps a slice in a List interface
+func WrapList[T any](items []T) List[T] {
+	return &list[T]{0, items[:]}
+}
+
+type consumer[T any] struct {
+	c        <-chan T
+	hasvalue bool
+	value    T
+}
+
+func (i *consumer[T]) MoveNext() bool {
+	i.value, i.hasvalue = <-i.c
+	return i.hasvalue
+}
+
+func (i *consumer[T]) Value() T {
+	return i.value
+}
@@ -1,5 +1,13 @@
 package c3
 
+import (
+	"context"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
 var (
 	// An empty Iterable
 	emptyIterable Iterable = &nilIterable{}
@@ -27,12 +35,34 @@ func (x *nilIterator) MoveNext() bool { return false }
 
 func (x *nilIterator) Value() interface{} { return defaultElementValue }
 
+// Close closes the Iterator if it implements Closer.
+func Close(i Iterator) {
+	if c, ok := i.(Closer); ok {
+		c.Close()
+	}
+}
+
+// Err returns the error of the Iterator if it implements Errer, or nil.
+func Err(i Iterator) error {
+	if e, ok := i.(Errer); ok {
+		return e.Err()
+	}
+	return nil
+}
+
 // Sort sorts the list with the given Lesser function
 func Sort(l List, lesser Lesser) {
 	s := &Sorter{l, lesser}
 	s.Sort()
 }
 
+// SortStable sorts the list with the given Lesser function,
+// equal items keep their original order.
+func SortStable(l List, lesser Lesser) {
+	s := &Sorter{l, lesser}
+	s.SortStable()
+}
+
 // ToSlice makes a new slice of the items in an Iterable
 func ToSlice(c Iterable) []interface{} {
 	var slice []interface{}
@@ -47,6 +77,41 @@ func ToSlice(c Iterable) []interface{} {
 	return slice
 }
 
+// ToSliceE puts the items of the Iterable in a new slice,
+// returns the slice and the error that stopped the iteration, see Err.
+func ToSliceE(c Iterable) ([]interface{}, error) {
+	slice := make([]interface{}, 0, 4)
+	i := c.Iterator()
+	for i.MoveNext() {
+		slice = append(slice, i.Value())
+	}
+	return slice, Err(i)
+}
+
+// ToListE puts the items of the Iterable in a new List,
+// returns the List and the error that stopped the iteration, see Err.
+func ToListE(c Iterable) (List, error) {
+	l := NewList()
+	i := c.Iterator()
+	for i.MoveNext() {
+		l.Add(i.Value())
+	}
+	return l, Err(i)
+}
+
+// ForE applies the action to each item in the Iterable, and returns
+// the first error of the action or the error that stopped the iteration.
+func ForE(c Iterable, action ErrorAction) error {
+	i := c.Iterator()
+	defer Close(i)
+	for i.MoveNext() {
+		if err := action(i.Value()); err != nil {
+			return err
+		}
+	}
+	return Err(i)
+}
+
 // ToList creates a new List of the items in an Iterable
 func ToList(c Iterable) List {
 	l := NewList()
@@ -92,6 +157,52 @@ func ToSet(c Iterable) Set {
 	return set
 }
 
+// ToMap makes a new Map of the items in an Iterable, using the selectors
+// to compute the key and the value of every item. If more than one item
+// has the same key, the value of the last item is kept.
+func ToMap(c Iterable, keySelector, valueSelector Selector) Map {
+	m := NewMap()
+	for i := c.Iterator(); i.MoveNext(); {
+		item := i.Value()
+		m.Put(keySelector(item), valueSelector(item))
+	}
+	return m
+}
+
+// ToLookup makes a new Lookup of the items in an Iterable,
+// indexed by the key computed by the keySelector.
+func ToLookup(c Iterable, keySelector Selector) Lookup {
+	return toLookup(c.Iterator(), keySelector)
+}
+
+// toLookup puts the items of the Iterator in a new lookup.
+func toLookup(i Iterator, keySelector Selector) *lookup {
+	l := &lookup{newList(), make(map[interface{}]*grouping)}
+	for i.MoveNext() {
+		item := i.Value()
+		l.add(keySelector(item), item)
+	}
+	return l
+}
+
+// ToSortedList makes a new SortedList of the items in an Iterable
+func ToSortedList(c Iterable, less Lesser) SortedList {
+	l := NewSortedList(less)
+	for i := c.Iterator(); i.MoveNext(); {
+		l.Add(i.Value())
+	}
+	return l
+}
+
+// ToSortedSet makes a new SortedSet of the unique items in an Iterable
+func ToSortedSet(c Iterable, less Lesser) SortedSet {
+	s := NewSortedSet(less)
+	for i := c.Iterator(); i.MoveNext(); {
+		s.Add(i.Value())
+	}
+	return s
+}
+
 // ToStack makes a new Stack of the items in an Iterable
 func ToStack(c Iterable) Stack {
 	s := NewStack()
@@ -101,6 +212,15 @@ func ToStack(c Iterable) Stack {
 	return s
 }
 
+// ToDeque makes a new Deque of the items in an Iterable
+func ToDeque(c Iterable) Deque {
+	d := NewDeque()
+	for i := c.Iterator(); i.MoveNext(); {
+		d.PushBack(i.Value())
+	}
+	return d
+}
+
 // ToQueue makes a new Queue of the items in an Iterable
 func ToQueue(c Iterable) Queue {
 	s := NewQueue()
@@ -143,6 +263,137 @@ func GoBuffered(c Iterable, bufferSize int, action Action) {
 	For(c, func(value interface{}) { ch <- value })
 }
 
+// GoWait applies the action to each item in the Iterable on the given number
+// of worker goroutines, or on runtime.GOMAXPROCS(0) workers if workers is less than 1,
+// using an unbuffered channel. GoWait returns after every action has completed.
+// A failing action does not stop the other actions, the returned error joins the
+// errors of the actions, a *PanicError for every action that panicked and the error
+// that stopped the iteration, see Err, or is nil if every action succeeded.
+func GoWait(c Iterable, workers int, action ErrorAction) error {
+	return GoBufferedWait(c, workers, 0, action)
+}
+
+// GoBufferedWait is GoWait using a buffered channel of the supplied size.
+func GoBufferedWait(c Iterable, workers, bufferSize int, action ErrorAction) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	ch := make(chan interface{}, bufferSize)
+	var mutex sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for n := 0; n < workers; n++ {
+		go func() {
+			defer wg.Done()
+			for value := range ch {
+				if err := try(action, value); err != nil {
+					mutex.Lock()
+					errs = append(errs, err)
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	i := c.Iterator()
+	func() {
+		// wait for the workers, even if the iteration panics
+		defer wg.Wait()
+		defer close(ch)
+		defer Close(i)
+		for i.MoveNext() {
+			ch <- i.Value()
+		}
+	}()
+	// the error that stopped the iteration follows the errors of the actions
+	return errors.Join(append(errs, Err(i))...)
+}
+
+// ToChannel sends the items of the Iterable to a new channel with the given
+// buffer size on a separate goroutine, and closes the channel after the last item.
+// Calling cancel stops the goroutine, closes the channel, and waits until the
+// iterator of the Iterable is closed, so the Iterable can be modified after cancel returns.
+// A receiver that stops receiving before the channel is closed must call cancel
+// to not leak the goroutine.
+func ToChannel(c Iterable, bufferSize int) (ch <-chan interface{}, cancel func()) {
+	ctx, stop := context.WithCancel(context.Background())
+	ch, done := toChannel(ctx, c, bufferSize)
+	return ch, func() {
+		stop()
+		<-done
+	}
+}
+
+// ToChannelContext sends the items of the Iterable to a new channel with the given
+// buffer size on a separate goroutine, and closes the channel after the last item,
+// or when the context is done. The iterator of the Iterable is closed before the
+// channel is closed, so a receiver must receive until the channel is closed
+// before it modifies the Iterable.
+func ToChannelContext(ctx context.Context, c Iterable, bufferSize int) <-chan interface{} {
+	ch, _ := toChannel(ctx, c, bufferSize)
+	return ch
+}
+
+// toChannel starts the goroutine of ToChannel,
+// done is closed when the goroutine has closed the iterator.
+func toChannel(ctx context.Context, c Iterable, bufferSize int) (<-chan interface{}, <-chan struct{}) {
+	ch := make(chan interface{}, bufferSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		defer close(done)
+		i := c.Iterator()
+		defer Close(i)
+		for ctx.Err() == nil && i.MoveNext() {
+			select {
+			case ch <- i.Value():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, done
+}
+
+// PumpToBag adds the items received from the channel to the Bag
+// until the channel is closed, and returns the number of items added.
+func PumpToBag(ch <-chan interface{}, b Bag) int {
+	return pump(ch, b.Add)
+}
+
+// PumpToQueue enqueues the items received from the channel
+// until the channel is closed, and returns the number of items enqueued.
+// A BlockingQueue receives the items with Put, which waits for free capacity,
+// the items received after the BlockingQueue is closed are dropped.
+func PumpToQueue(ch <-chan interface{}, q Queue) int {
+	if bq, ok := q.(BlockingQueue); ok {
+		return pump(ch, func(item interface{}) bool {
+			return bq.Put(item) == nil
+		})
+	}
+	return pump(ch, q.Enqueue)
+}
+
+func pump(ch <-chan interface{}, add func(item interface{}) bool) int {
+	count := 0
+	for item := range ch {
+		if add(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// try applies the action to the item, and returns a *PanicError if the action panics.
+func try(action ErrorAction, item interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{item, r, debug.Stack()}
+		}
+	}()
+	return action(item)
+}
+
 // Repeat repeats the item count times.
 //
 // e.g.:
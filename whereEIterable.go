@@ -0,0 +1,10 @@
+package c3
+
+type whereEIterable struct {
+	items Iterable
+	where PredicateE
+}
+
+func (i *whereEIterable) Iterator() Iterator {
+	return &whereEIterator{items: i.items.Iterator(), where: i.where}
+}
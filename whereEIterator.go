@@ -0,0 +1,43 @@
+package c3
+
+type whereEIterator struct {
+	items Iterator
+	where PredicateE
+	err   error
+}
+
+func (i *whereEIterator) MoveNext() bool {
+	if i.err != nil {
+		return false
+	}
+	for i.items.MoveNext() {
+		ok, err := i.where(i.items.Value())
+		if err != nil {
+			i.err = err
+			Close(i.items)
+			return false
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *whereEIterator) Value() interface{} {
+	if i.err != nil {
+		return defaultElementValue
+	}
+	return i.items.Value()
+}
+
+func (i *whereEIterator) Close() {
+	Close(i.items)
+}
+
+func (i *whereEIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return Err(i.items)
+}
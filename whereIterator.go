@@ -17,3 +17,11 @@ func (i *whereIterator) MoveNext() bool {
 func (i *whereIterator) Value() interface{} {
 	return i.items.Value()
 }
+
+func (i *whereIterator) Close() {
+	Close(i.items)
+}
+
+func (i *whereIterator) Err() error {
+	return Err(i.items)
+}
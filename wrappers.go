@@ -1,11 +1,51 @@
 package c3
 
+import "context"
+
 // WrapConsumer wraps a channel in a consuming Iterator
 func WrapConsumer(c <-chan interface{}) Consumer {
 	return &consumer{c, false, defaultElementValue}
 }
 
+// WrapConsumerContext wraps a channel in a consuming Iterator,
+// whose MoveNext returns false when the context is done.
+// Err of the consumer returns the error of the context after that, see Err.
+func WrapConsumerContext(ctx context.Context, c <-chan interface{}) Consumer {
+	return &contextConsumer{ctx: ctx, c: c}
+}
+
+// FromChannel creates a query over the items received from the channel.
+// The received items are kept, so the query can be iterated more than once,
+// and iterating waits for items until the channel is closed.
+func FromChannel(ch <-chan interface{}) *Q {
+	return NewQuery(&channelIterable{ch: ch})
+}
+
 // Wraps a slice in a List interface
 func WrapList(items []interface{}) List {
-	return &list{0, items[:]}
+	return &list{0, items[:], nil}
+}
+
+// SynchronizedList wraps a List in a SyncList that guards every call
+// with a sync.RWMutex. The wrapped list must not be used directly afterwards.
+func SynchronizedList(l List) SyncList {
+	return &syncList{l: l}
+}
+
+// SynchronizedSet wraps a Set in a SyncSet that guards every call
+// with a sync.RWMutex. The wrapped set must not be used directly afterwards.
+func SynchronizedSet(s Set) SyncSet {
+	return &syncSet{s: s}
+}
+
+// SynchronizedQueue wraps a Queue in a SyncQueue that guards every call
+// with a sync.RWMutex. The wrapped queue must not be used directly afterwards.
+func SynchronizedQueue(q Queue) SyncQueue {
+	return &syncQueue{q: q}
+}
+
+// SynchronizedStack wraps a Stack in a SyncStack that guards every call
+// with a sync.RWMutex. The wrapped stack must not be used directly afterwards.
+func SynchronizedStack(s Stack) SyncStack {
+	return &syncStack{s: s}
 }